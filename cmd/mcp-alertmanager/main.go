@@ -9,28 +9,53 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/completions"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/kubernetes"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/prometheus"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/prompts"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/redact"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/resources"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/schedule"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/version"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/watcher"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 type options struct {
-	Version       bool
-	LogLevel      int
-	Port          string
-	URL           string
-	Namespace     string
-	Service       string
-	ServicePort   string
-	ServiceScheme string
-	Kubeconfig    string
+	Version          bool
+	LogLevel         int
+	Port             string
+	URL              string
+	Namespace        string
+	Service          string
+	ServicePort      string
+	ServiceScheme    string
+	Kubeconfig       string
+	RedactPattern    []string
+	LabelAllow       []string
+	LabelDeny        []string
+	AnnotationAllow  []string
+	AnnotationDeny   []string
+	MaxOutputBytes   int
+	PollInterval     time.Duration
+	HistoryFile      string
+	HistoryRetention time.Duration
+	WebhookPath      string
+	WebhookToken     string
+	PrometheusURL    string
+	ScheduleFile     string
+	TimeZone         string
 }
 
 func main() {
@@ -68,6 +93,20 @@ func newCommand() *cobra.Command {
 	cmd.Flags().StringVar(&o.ServicePort, "service-port", "", "Kubernetes service port for Alertmanager (default: 9093)")
 	cmd.Flags().StringVar(&o.ServiceScheme, "service-scheme", "", "Kubernetes service scheme: http or https (default: https)")
 	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: auto-detect)")
+	cmd.Flags().StringArrayVar(&o.RedactPattern, "redact-pattern", nil, "Additional regex whose matches are masked in all output (repeatable)")
+	cmd.Flags().StringSliceVar(&o.LabelAllow, "label-allow", nil, "Only show these alert label keys (comma-separated, globs allowed)")
+	cmd.Flags().StringSliceVar(&o.LabelDeny, "label-deny", nil, "Hide these alert label keys (comma-separated, globs allowed)")
+	cmd.Flags().StringSliceVar(&o.AnnotationAllow, "annotation-allow", nil, "Only show these alert annotation keys (comma-separated, globs allowed)")
+	cmd.Flags().StringSliceVar(&o.AnnotationDeny, "annotation-deny", nil, "Hide these alert annotation keys (comma-separated, globs allowed)")
+	cmd.Flags().IntVar(&o.MaxOutputBytes, "max-output-bytes", output.DefaultMaxBytes, "Maximum size of a tool result in bytes; larger lists are paged, larger text is truncated and larger JSON or structured results are refused (0 disables)")
+	cmd.Flags().DurationVar(&o.PollInterval, "poll-interval", watcher.DefaultInterval, "How often to poll Alertmanager for changes to notify resource subscribers about and record in the history; polling starts at the first subscription, or at startup with --history-file (0 disables)")
+	cmd.Flags().StringVar(&o.HistoryFile, "history-file", "", "Record alert history in this file; enables history answers in getAlertHistory (default: disabled)")
+	cmd.Flags().DurationVar(&o.HistoryRetention, "history-retention", history.DefaultRetention, "How long to keep resolved alerts in the history")
+	cmd.Flags().StringVar(&o.WebhookPath, "webhook-path", "", "HTTP path of the Alertmanager webhook receiver that records notifications in the history, e.g. /webhook (requires --port, --history-file and --webhook-token; default: disabled)")
+	cmd.Flags().StringVar(&o.WebhookToken, "webhook-token", "", "Bearer token required by the webhook receiver. Env: WEBHOOK_TOKEN")
+	cmd.Flags().StringVar(&o.PrometheusURL, "prometheus-url", "", "Prometheus or Thanos Querier URL to reconstruct alert history from the ALERTS series when --history-file is not set (default: thanos-querier on OpenShift). Env: PROMETHEUS_URL")
+	cmd.Flags().StringVar(&o.ScheduleFile, "schedule-file", "", "Create silences for the recurring maintenance windows in this file ahead of each window (requires --port; default: disabled)")
+	cmd.Flags().StringVar(&o.TimeZone, "time-zone", "", "IANA time zone of times given without one, e.g. 'until 18:00' or schedule windows (default: local time zone). Env: TZ")
 
 	return cmd
 }
@@ -84,6 +123,84 @@ func (o *options) run() error {
 
 	client := alertmanager.NewClient(baseURL, httpClient)
 
+	if o.TimeZone != "" {
+		loc, err := time.LoadLocation(o.TimeZone)
+		if err != nil {
+			return fmt.Errorf("invalid --time-zone: %w", err)
+		}
+		timeutil.SetLocation(loc)
+	}
+
+	redactor, err := redact.New(o.RedactPattern)
+	if err != nil {
+		return err
+	}
+
+	if o.WebhookPath != "" {
+		if o.WebhookToken == "" {
+			o.WebhookToken = os.Getenv("WEBHOOK_TOKEN")
+		}
+		if o.Port == "" || o.HistoryFile == "" || o.WebhookToken == "" {
+			return fmt.Errorf("--webhook-path requires --port, --history-file and --webhook-token")
+		}
+	}
+
+	var store *history.Store
+	var historySource history.Source
+	if o.HistoryFile != "" {
+		if o.PollInterval <= 0 && (o.Port == "" || o.WebhookPath == "") {
+			return fmt.Errorf("--history-file requires a positive --poll-interval or the webhook receiver")
+		}
+		if store, err = history.Open(o.HistoryFile, o.HistoryRetention); err != nil {
+			return err
+		}
+		historySource = store
+	} else if prometheusURL, prometheusClient := o.resolvePrometheus(); prometheusURL != "" {
+		historySource = prometheus.NewHistory(prometheus.NewClient(prometheusURL, prometheusClient))
+	}
+
+	var scheduler *schedule.Scheduler
+	if o.ScheduleFile != "" {
+		if o.Port == "" {
+			return fmt.Errorf("--schedule-file requires --port")
+		}
+		if scheduler, err = schedule.Load(o.ScheduleFile, client); err != nil {
+			return err
+		}
+	}
+
+	var cluster *kubernetes.Cluster
+	if kubernetes.CanConnectToCluster(o.Kubeconfig) {
+		if cluster, err = kubernetes.NewCluster(o.Kubeconfig); err != nil {
+			klog.V(1).Infof("Kubernetes lookups disabled: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serverOptions := &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{
+			Tools:   &mcp.ToolCapabilities{ListChanged: true},
+			Logging: &mcp.LoggingCapabilities{},
+		},
+		CompletionHandler: completions.Handler(client),
+	}
+	// Poll Alertmanager only once a client subscribes to a resource, or
+	// from the start to record the history
+	var w *watcher.Watcher
+	if o.PollInterval > 0 {
+		w = watcher.New(client, o.PollInterval)
+		serverOptions.SubscribeHandler = func(requestCtx context.Context, request *mcp.SubscribeRequest) error {
+			if err := resources.Subscribe(requestCtx, request); err != nil {
+				return err
+			}
+			w.Start(ctx)
+			return nil
+		}
+		serverOptions.UnsubscribeHandler = resources.Unsubscribe
+	}
+
 	server := mcp.NewServer(
 		&mcp.Implementation{
 			Name:       version.BinaryName,
@@ -91,18 +208,23 @@ func (o *options) run() error {
 			Version:    version.Version,
 			WebsiteURL: version.WebsiteURL,
 		},
-		&mcp.ServerOptions{
-			Capabilities: &mcp.ServerCapabilities{
-				Tools:   &mcp.ToolCapabilities{ListChanged: true},
-				Logging: &mcp.LoggingCapabilities{},
-			},
-		},
+		serverOptions,
 	)
 
-	toolsets.RegisterAll(server, client)
+	output.SetFieldFilter(output.FieldFilter{
+		LabelAllow:      o.LabelAllow,
+		LabelDeny:       o.LabelDeny,
+		AnnotationAllow: o.AnnotationAllow,
+		AnnotationDeny:  o.AnnotationDeny,
+	})
+	output.SetMaxBytes(o.MaxOutputBytes)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Mask secrets in everything returned to the client
+	server.AddReceivingMiddleware(output.LimitMiddleware, redactor.Middleware)
+
+	toolsets.RegisterAll(server, client, toolsets.Options{History: historySource, Cluster: cluster})
+	resources.Register(server, client)
+	prompts.Register(server, client)
 
 	// Graceful shutdown on signals
 	sigCh := make(chan os.Signal, 1)
@@ -113,14 +235,33 @@ func (o *options) run() error {
 		cancel()
 	}()
 
+	// Notify resource subscribers when alerts and silences change, and record the history
+	if w != nil {
+		w.OnPoll(resources.Notify(server))
+		if store != nil {
+			w.OnPoll(store.Update)
+			w.Start(ctx)
+		}
+	}
+
+	// Create the silences of recurring maintenance windows ahead of time
+	if scheduler != nil {
+		go scheduler.Run(ctx)
+	}
+
 	if o.Port != "" {
 		klog.V(1).Infof("Starting HTTP server on port %s", o.Port)
-		handler := mcp.NewStreamableHTTPHandler(func(request *http.Request) *mcp.Server {
+		mux := http.NewServeMux()
+		mux.Handle("/", mcp.NewStreamableHTTPHandler(func(request *http.Request) *mcp.Server {
 			return server
-		}, &mcp.StreamableHTTPOptions{})
+		}, &mcp.StreamableHTTPOptions{}))
+		if store != nil && o.WebhookPath != "" {
+			klog.V(1).Infof("Accepting Alertmanager webhook notifications on %s", o.WebhookPath)
+			mux.Handle(o.WebhookPath, store.WebhookHandler(o.WebhookToken))
+		}
 		httpServer := &http.Server{
 			Addr:    ":" + o.Port,
-			Handler: handler,
+			Handler: mux,
 		}
 		go func() {
 			<-ctx.Done()
@@ -229,3 +370,40 @@ Configure one of the following:
   # Explicit kubeconfig
   %[1]s --kubeconfig /path/to/kubeconfig`, version.BinaryName)
 }
+
+// resolvePrometheus determines how to connect to Prometheus for alert history.
+// Priority: --prometheus-url flag / PROMETHEUS_URL env → OpenShift thanos-querier (in-cluster: internal service, local: route).
+// Returns an empty URL if none is available; history then comes from Alertmanager only.
+func (o *options) resolvePrometheus() (string, *http.Client) {
+	url := o.PrometheusURL
+	if url == "" {
+		url = os.Getenv("PROMETHEUS_URL")
+	}
+	if url != "" {
+		klog.V(1).Infof("Using direct Prometheus URL: %s", url)
+		return url, nil
+	}
+
+	if !kubernetes.CanConnectToCluster(o.Kubeconfig) || !kubernetes.IsOpenShift(o.Kubeconfig) {
+		return "", nil
+	}
+	if kubernetes.IsInCluster() {
+		// Requires the cluster-monitoring-view ClusterRole
+		serviceURL, httpClient, err := kubernetes.NewOpenShiftServiceClient(o.Kubeconfig, "openshift-monitoring", "thanos-querier", "9091")
+		if err != nil {
+			klog.V(2).Infof("Thanos Querier internal service connection failed: %v", err)
+		}
+		if serviceURL != "" {
+			klog.V(1).Infof("Using Thanos Querier internal service: %s", serviceURL)
+		}
+		return serviceURL, httpClient
+	}
+	routeURL, httpClient, err := kubernetes.NewOpenShiftRouteClient(o.Kubeconfig, "openshift-monitoring", "thanos-querier")
+	if err != nil {
+		klog.V(2).Infof("Thanos Querier route connection failed: %v", err)
+	}
+	if routeURL != "" {
+		klog.V(1).Infof("Using Thanos Querier route: %s", routeURL)
+	}
+	return routeURL, httpClient
+}
@@ -77,61 +77,41 @@ func (c *Client) doDelete(path string) error {
 	return nil
 }
 
-// GetAlerts returns alerts from Alertmanager.
-func (c *Client) GetAlerts(active, silenced, inhibited, filterLabel string) (string, error) {
-	params := url.Values{}
-	if active != "" {
-		params.Set("active", active)
-	}
-	if silenced != "" {
-		params.Set("silenced", silenced)
-	}
-	if inhibited != "" {
-		params.Set("inhibited", inhibited)
-	}
-	if filterLabel != "" {
-		params.Set("filter", filterLabel)
-	}
-	path := "/api/v2/alerts"
-	if len(params) > 0 {
-		path += "?" + params.Encode()
-	}
-	body, err := c.doGet(path)
+// GetSilences returns silences from Alertmanager, optionally filtered by state.
+func (c *Client) GetSilences(state string) ([]GettableSilence, error) {
+	body, err := c.doGet("/api/v2/silences")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return formatJSON(body)
-}
-
-// GetAlertGroups returns alerts grouped by routing labels.
-func (c *Client) GetAlertGroups() (string, error) {
-	body, err := c.doGet("/api/v2/alerts/groups")
-	if err != nil {
-		return "", err
+	var silences []GettableSilence
+	if err := json.Unmarshal(body, &silences); err != nil {
+		return nil, fmt.Errorf("parsing silences: %w", err)
 	}
-	return formatJSON(body)
-}
-
-// GetSilences returns silences from Alertmanager.
-func (c *Client) GetSilences(state string) (string, error) {
-	path := "/api/v2/silences"
-	if state != "" {
-		path += "?state=" + url.QueryEscape(state)
+	if state == "" {
+		return silences, nil
 	}
-	body, err := c.doGet(path)
-	if err != nil {
-		return "", err
+	filtered := silences[:0]
+	for _, s := range silences {
+		if s.Status.State == state {
+			filtered = append(filtered, s)
+		}
 	}
-	return formatJSON(body)
+	return filtered, nil
 }
 
-// CreateSilence creates a new silence.
+// CreateSilence creates a new silence and returns its ID.
 func (c *Client) CreateSilence(silence PostableSilence) (string, error) {
 	body, err := c.doPost("/api/v2/silences", silence)
 	if err != nil {
 		return "", err
 	}
-	return formatJSON(body)
+	var resp struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("parsing silence response: %w", err)
+	}
+	return resp.SilenceID, nil
 }
 
 // DeleteSilence deletes a silence by ID.
@@ -149,16 +129,21 @@ func (c *Client) GetStatus() (string, error) {
 }
 
 // GetReceivers returns configured notification receivers.
-func (c *Client) GetReceivers() (string, error) {
+func (c *Client) GetReceivers() ([]Receiver, error) {
 	body, err := c.doGet("/api/v2/receivers")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return formatJSON(body)
+	var receivers []Receiver
+	if err := json.Unmarshal(body, &receivers); err != nil {
+		return nil, fmt.Errorf("parsing receivers: %w", err)
+	}
+	return receivers, nil
 }
 
 // GetAlertsRaw returns raw alert data for processing.
-func (c *Client) GetAlertsRaw(active, silenced, inhibited string) ([]GettableAlert, error) {
+// Each filter is a label matcher such as `severity="critical"`.
+func (c *Client) GetAlertsRaw(active, silenced, inhibited string, filters ...string) ([]GettableAlert, error) {
 	params := url.Values{}
 	if active != "" {
 		params.Set("active", active)
@@ -169,6 +154,11 @@ func (c *Client) GetAlertsRaw(active, silenced, inhibited string) ([]GettableAle
 	if inhibited != "" {
 		params.Set("inhibited", inhibited)
 	}
+	for _, f := range filters {
+		if f != "" {
+			params.Add("filter", f)
+		}
+	}
 	path := "/api/v2/alerts"
 	if len(params) > 0 {
 		path += "?" + params.Encode()
@@ -184,6 +174,41 @@ func (c *Client) GetAlertsRaw(active, silenced, inhibited string) ([]GettableAle
 	return alerts, nil
 }
 
+// GetAlertGroupsRaw returns raw alert group data for processing.
+func (c *Client) GetAlertGroupsRaw() ([]AlertGroup, error) {
+	body, err := c.doGet("/api/v2/alerts/groups")
+	if err != nil {
+		return nil, err
+	}
+	var groups []AlertGroup
+	if err := json.Unmarshal(body, &groups); err != nil {
+		return nil, fmt.Errorf("parsing alert groups: %w", err)
+	}
+	return groups, nil
+}
+
+// GetStatusRaw returns raw Alertmanager status data for processing.
+func (c *Client) GetStatusRaw() (*AlertmanagerStatus, error) {
+	body, err := c.doGet("/api/v2/status")
+	if err != nil {
+		return nil, err
+	}
+	var status AlertmanagerStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("parsing status: %w", err)
+	}
+	return &status, nil
+}
+
+// GetConfig returns the parsed running Alertmanager configuration.
+func (c *Client) GetConfig() (*Config, error) {
+	status, err := c.GetStatusRaw()
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(status.Config.Original)
+}
+
 func formatJSON(data []byte) (string, error) {
 	var obj interface{}
 	if err := json.Unmarshal(data, &obj); err != nil {
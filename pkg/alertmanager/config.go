@@ -0,0 +1,154 @@
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the subset of the Alertmanager configuration file (alertmanager.yml)
+// needed to reason about routing, receivers and inhibition.
+type Config struct {
+	Global            map[string]any   `json:"global,omitempty"`
+	Route             *Route           `json:"route,omitempty"`
+	Receivers         []ReceiverConfig `json:"receivers,omitempty"`
+	InhibitRules      []InhibitRule    `json:"inhibit_rules,omitempty"`
+	Templates         []string         `json:"templates,omitempty"`
+	MuteTimeIntervals []TimeInterval   `json:"mute_time_intervals,omitempty"`
+	TimeIntervals     []TimeInterval   `json:"time_intervals,omitempty"`
+	Tracing           map[string]any   `json:"tracing,omitempty"`
+}
+
+// Route is a node of the routing tree as written in the configuration file.
+// Unset fields are inherited from the parent route.
+type Route struct {
+	Receiver            string            `json:"receiver,omitempty"`
+	GroupBy             []string          `json:"group_by,omitempty"`
+	Continue            bool              `json:"continue,omitempty"`
+	Match               map[string]string `json:"match,omitempty"`
+	MatchRE             map[string]string `json:"match_re,omitempty"`
+	Matchers            []string          `json:"matchers,omitempty"`
+	GroupWait           string            `json:"group_wait,omitempty"`
+	GroupInterval       string            `json:"group_interval,omitempty"`
+	RepeatInterval      string            `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals   []string          `json:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string          `json:"active_time_intervals,omitempty"`
+	Routes              []*Route          `json:"routes,omitempty"`
+}
+
+// ReceiverConfig is a receiver definition. Integrations holds every
+// *_configs block (slack_configs, webhook_configs, ...) keyed by type.
+type ReceiverConfig struct {
+	Name         string         `json:"name"`
+	Integrations map[string]any `json:"-"`
+}
+
+// UnmarshalJSON captures the receiver name and keeps all integration blocks.
+// Any other key is rejected, so that misspelled blocks are reported.
+func (r *ReceiverConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	name, _ := raw["name"].(string)
+	delete(raw, "name")
+	for key := range raw {
+		if !strings.HasSuffix(key, "_configs") {
+			return fmt.Errorf("receiver %q: unknown field %q", name, key)
+		}
+	}
+	r.Name = name
+	r.Integrations = raw
+	return nil
+}
+
+// MarshalJSON writes the receiver name alongside its integration blocks.
+func (r ReceiverConfig) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(r.Integrations)+1)
+	for k, v := range r.Integrations {
+		out[k] = v
+	}
+	out["name"] = r.Name
+	return json.Marshal(out)
+}
+
+// InhibitRule mutes target alerts while matching source alerts are firing.
+type InhibitRule struct {
+	SourceMatch    map[string]string `json:"source_match,omitempty"`
+	SourceMatchRE  map[string]string `json:"source_match_re,omitempty"`
+	SourceMatchers []string          `json:"source_matchers,omitempty"`
+	TargetMatch    map[string]string `json:"target_match,omitempty"`
+	TargetMatchRE  map[string]string `json:"target_match_re,omitempty"`
+	TargetMatchers []string          `json:"target_matchers,omitempty"`
+	Equal          []string          `json:"equal,omitempty"`
+}
+
+// TimeInterval is a named mute/active time interval definition.
+type TimeInterval struct {
+	Name          string `json:"name"`
+	TimeIntervals []any  `json:"time_intervals,omitempty"`
+}
+
+// ParseConfig parses an Alertmanager configuration in YAML form,
+// such as the one returned in ConfigStatus.Original. Unknown keys, such as
+// misspelled ones, are errors.
+func ParseConfig(original string) (*Config, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict([]byte(original), &cfg); err != nil {
+		return nil, fmt.Errorf("parsing configuration: %w", err)
+	}
+	if cfg.Route == nil {
+		return nil, fmt.Errorf("parsing configuration: no top-level route defined")
+	}
+	return &cfg, nil
+}
+
+// RouteMatchers returns the route's match, match_re and matchers entries as
+// a single matcher list.
+func (r *Route) RouteMatchers() ([]Matcher, error) {
+	var matchers []Matcher
+	for _, name := range sortedKeys(r.Match) {
+		matchers = append(matchers, Matcher{IsEqual: true, Name: name, Value: r.Match[name]})
+	}
+	for _, name := range sortedKeys(r.MatchRE) {
+		if _, err := compileRegex(r.MatchRE[name]); err != nil {
+			return nil, fmt.Errorf("invalid match_re %s: %w", name, err)
+		}
+		matchers = append(matchers, Matcher{IsEqual: true, IsRegex: true, Name: name, Value: r.MatchRE[name]})
+	}
+	for _, s := range r.Matchers {
+		parsed, err := ParseMatchers(s)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, parsed...)
+	}
+	return matchers, nil
+}
+
+// SourceMatcherList returns the inhibit rule's source matchers.
+func (r *InhibitRule) SourceMatcherList() ([]Matcher, error) {
+	return combineMatchers(r.SourceMatch, r.SourceMatchRE, r.SourceMatchers)
+}
+
+// TargetMatcherList returns the inhibit rule's target matchers.
+func (r *InhibitRule) TargetMatcherList() ([]Matcher, error) {
+	return combineMatchers(r.TargetMatch, r.TargetMatchRE, r.TargetMatchers)
+}
+
+func combineMatchers(match, matchRE map[string]string, list []string) ([]Matcher, error) {
+	route := Route{Match: match, MatchRE: matchRE, Matchers: list}
+	return route.RouteMatchers()
+}
+
+// Receiver returns the receiver definition with the given name, or nil.
+func (c *Config) Receiver(name string) *ReceiverConfig {
+	for i := range c.Receivers {
+		if c.Receivers[i].Name == name {
+			return &c.Receivers[i]
+		}
+	}
+	return nil
+}
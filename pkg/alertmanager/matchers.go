@@ -0,0 +1,188 @@
+package alertmanager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var regexCache sync.Map // pattern -> *regexp.Regexp
+
+// ParseMatcher parses a single matcher in Alertmanager syntax,
+// e.g. `severity="critical"`, `namespace=~"team-.*"` or `job!=node`.
+func ParseMatcher(s string) (Matcher, error) {
+	s = strings.TrimSpace(s)
+	idx := strings.IndexAny(s, "=!")
+	if idx <= 0 {
+		return Matcher{}, fmt.Errorf("invalid matcher %q: expected name, operator and value", s)
+	}
+	name := strings.TrimSpace(s[:idx])
+	rest := s[idx:]
+
+	m := Matcher{Name: name}
+	switch {
+	case strings.HasPrefix(rest, "=~"):
+		m.IsEqual, m.IsRegex = true, true
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "!~"):
+		m.IsEqual, m.IsRegex = false, true
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "!="):
+		m.IsEqual = false
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "="):
+		m.IsEqual = true
+		rest = rest[1:]
+	default:
+		return Matcher{}, fmt.Errorf("invalid matcher %q: unknown operator", s)
+	}
+
+	value := strings.TrimSpace(rest)
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return Matcher{}, fmt.Errorf("invalid matcher %q: bad quoted value: %w", s, err)
+		}
+		value = unquoted
+	}
+	m.Value = value
+
+	if !validLabelName(name) {
+		return Matcher{}, fmt.Errorf("invalid matcher %q: invalid label name %q", s, name)
+	}
+	if m.IsRegex {
+		if _, err := compileRegex(m.Value); err != nil {
+			return Matcher{}, fmt.Errorf("invalid matcher %q: %w", s, err)
+		}
+	}
+	return m, nil
+}
+
+// ParseMatchers parses a matcher list such as `{alertname="Foo", severity=~"warning|critical"}`.
+// The surrounding braces are optional.
+func ParseMatchers(s string) ([]Matcher, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "{")
+	s = strings.TrimSuffix(s, "}")
+	var matchers []Matcher
+	for _, part := range splitMatchers(s) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		m, err := ParseMatcher(part)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// splitMatchers splits on commas that are not inside quoted values.
+func splitMatchers(s string) []string {
+	var parts []string
+	var sb strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ',' && !inQuotes:
+			parts = append(parts, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return append(parts, sb.String())
+}
+
+// Matches reports whether the matcher matches the given label set.
+// A missing label is treated as an empty value, as Alertmanager does.
+func (m Matcher) Matches(labels map[string]string) bool {
+	value := labels[m.Name]
+	var ok bool
+	if m.IsRegex {
+		re, err := compileRegex(m.Value)
+		if err != nil {
+			return false
+		}
+		ok = re.MatchString(value)
+	} else {
+		ok = value == m.Value
+	}
+	if m.IsEqual {
+		return ok
+	}
+	return !ok
+}
+
+// Operator returns the matcher operator: =, !=, =~ or !~.
+func (m Matcher) Operator() string {
+	switch {
+	case m.IsEqual && m.IsRegex:
+		return "=~"
+	case !m.IsEqual && m.IsRegex:
+		return "!~"
+	case !m.IsEqual:
+		return "!="
+	default:
+		return "="
+	}
+}
+
+// String returns the matcher in Alertmanager syntax.
+func (m Matcher) String() string {
+	return m.Name + m.Operator() + strconv.Quote(m.Value)
+}
+
+// MatchAll reports whether all matchers match the given label set.
+func MatchAll(matchers []Matcher, labels map[string]string) bool {
+	for _, m := range matchers {
+		if !m.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatMatchers returns a matcher list in Alertmanager syntax.
+func FormatMatchers(matchers []Matcher) string {
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = m.String()
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// compileRegex compiles an anchored regular expression, caching the result.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(pattern, re)
+	return re, nil
+}
+
+func validLabelName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package alertmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMatchers(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{in: `{alertname="Foo", severity=~"warning|critical"}`, want: `{alertname="Foo", severity=~"warning|critical"}`},
+		{in: `job!=node`, want: `{job!="node"}`},
+		{in: `namespace!~"kube-.*" , team = "a,b"`, want: `{namespace!~"kube-.*", team="a,b"}`},
+		{in: `msg="say \"hi\", then go"`, want: `{msg="say \"hi\", then go"}`},
+		{in: `{}`, want: `{}`},
+		{in: `severity`, wantErr: "expected name, operator and value"},
+		{in: `=foo`, wantErr: "expected name, operator and value"},
+		{in: `1abc="x"`, wantErr: "invalid label name"},
+		{in: `x=~"("`, wantErr: "missing closing )"},
+		{in: `x="unterminated`, wantErr: "bad quoted value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMatchers(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseMatchers(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := FormatMatchers(got); s != tt.want {
+				t.Errorf("ParseMatchers(%q) = %s, want %s", tt.in, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherMatches(t *testing.T) {
+	labels := map[string]string{"alertname": "HighCPU", "namespace": "team-a"}
+	tests := []struct {
+		matcher string
+		want    bool
+	}{
+		{`alertname="HighCPU"`, true},
+		{`alertname!="HighCPU"`, false},
+		{`namespace=~"team-.*"`, true},
+		{`namespace=~"team"`, false}, // regexes are anchored
+		{`namespace!~"kube-.*"`, true},
+		{`severity=""`, true}, // a missing label is empty
+		{`severity!=""`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.matcher, func(t *testing.T) {
+			m, err := ParseMatcher(tt.matcher)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.Matches(labels); got != tt.want {
+				t.Errorf("%s.Matches() = %t, want %t", tt.matcher, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package alertmanager
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// RouteNode is a routing tree node with all inherited settings resolved.
+type RouteNode struct {
+	ID                  string       `json:"id"`
+	Depth               int          `json:"depth"`
+	Receiver            string       `json:"receiver"`
+	GroupBy             []string     `json:"groupBy,omitempty"`
+	Matchers            []Matcher    `json:"matchers,omitempty"`
+	Continue            bool         `json:"continue,omitempty"`
+	GroupWait           string       `json:"groupWait,omitempty"`
+	GroupInterval       string       `json:"groupInterval,omitempty"`
+	RepeatInterval      string       `json:"repeatInterval,omitempty"`
+	MuteTimeIntervals   []string     `json:"muteTimeIntervals,omitempty"`
+	ActiveTimeIntervals []string     `json:"activeTimeIntervals,omitempty"`
+	Routes              []*RouteNode `json:"routes,omitempty"`
+}
+
+// RoutingTree resolves the configured route hierarchy. Receivers, grouping
+// and timings are inherited from parent routes as Alertmanager does.
+func (c *Config) RoutingTree() (*RouteNode, error) {
+	return resolveRoute(c.Route, nil, "root", 0)
+}
+
+func resolveRoute(r *Route, parent *RouteNode, id string, depth int) (*RouteNode, error) {
+	matchers, err := r.RouteMatchers()
+	if err != nil {
+		return nil, fmt.Errorf("route %s: %w", id, err)
+	}
+	node := &RouteNode{
+		ID:                  id,
+		Depth:               depth,
+		Receiver:            r.Receiver,
+		GroupBy:             r.GroupBy,
+		Matchers:            matchers,
+		Continue:            r.Continue,
+		GroupWait:           r.GroupWait,
+		GroupInterval:       r.GroupInterval,
+		RepeatInterval:      r.RepeatInterval,
+		MuteTimeIntervals:   r.MuteTimeIntervals,
+		ActiveTimeIntervals: r.ActiveTimeIntervals,
+	}
+	if parent != nil {
+		if node.Receiver == "" {
+			node.Receiver = parent.Receiver
+		}
+		if node.GroupBy == nil {
+			node.GroupBy = parent.GroupBy
+		}
+		if node.GroupWait == "" {
+			node.GroupWait = parent.GroupWait
+		}
+		if node.GroupInterval == "" {
+			node.GroupInterval = parent.GroupInterval
+		}
+		if node.RepeatInterval == "" {
+			node.RepeatInterval = parent.RepeatInterval
+		}
+	}
+	for i, child := range r.Routes {
+		if child == nil {
+			continue
+		}
+		childNode, err := resolveRoute(child, node, id+"."+strconv.Itoa(i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		node.Routes = append(node.Routes, childNode)
+	}
+	return node, nil
+}
+
+// Match returns the routes an alert with the given labels is delivered to.
+func (n *RouteNode) Match(labels map[string]string) []*RouteNode {
+	return n.match(labels, nil)
+}
+
+// Trace returns every route the alert passes through on its way to its
+// final routes, including the root and all intermediate routes.
+func (n *RouteNode) Trace(labels map[string]string) []*RouteNode {
+	var visited []*RouteNode
+	n.match(labels, &visited)
+	return visited
+}
+
+func (n *RouteNode) match(labels map[string]string, visited *[]*RouteNode) []*RouteNode {
+	// The root route matches every alert.
+	if n.Depth > 0 && !MatchAll(n.Matchers, labels) {
+		return nil
+	}
+	if visited != nil {
+		*visited = append(*visited, n)
+	}
+	var matched []*RouteNode
+	for _, child := range n.Routes {
+		m := child.match(labels, visited)
+		matched = append(matched, m...)
+		if len(m) > 0 && !child.Continue {
+			break
+		}
+	}
+	if len(matched) == 0 {
+		matched = []*RouteNode{n}
+	}
+	return matched
+}
+
+// Walk calls fn for the node and all its descendants in depth-first order.
+func (n *RouteNode) Walk(fn func(*RouteNode)) {
+	fn(n)
+	for _, child := range n.Routes {
+		child.Walk(fn)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
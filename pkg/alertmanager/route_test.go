@@ -0,0 +1,85 @@
+package alertmanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+const routingConfig = `
+route:
+  receiver: default
+  group_by: [alertname]
+  routes:
+  - matchers: ['severity="critical"']
+    receiver: pager
+    continue: true
+  - matchers: ['team="db"']
+    receiver: db
+    group_wait: 1m
+    routes:
+    - matchers: ['env="dev"']
+      receiver: db-dev
+  - matchers: ['team=~"db|web"']
+    receiver: web
+receivers:
+- name: default
+- name: pager
+- name: db
+- name: db-dev
+- name: web
+`
+
+func TestRouteMatch(t *testing.T) {
+	cfg, err := ParseConfig(routingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := cfg.RoutingTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []string
+	}{
+		{"no match falls back to root", map[string]string{"team": "other"}, []string{"root:default"}},
+		{"first match wins", map[string]string{"team": "db"}, []string{"root.1:db"}},
+		{"nested route", map[string]string{"team": "db", "env": "dev"}, []string{"root.1.0:db-dev"}},
+		{"later sibling", map[string]string{"team": "web"}, []string{"root.2:web"}},
+		{"continue", map[string]string{"severity": "critical", "team": "db"}, []string{"root.0:pager", "root.1:db"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, n := range tree.Match(tt.labels) {
+				got = append(got, n.ID+":"+n.Receiver)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutingTreeInheritance(t *testing.T) {
+	cfg, err := ParseConfig(routingConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := cfg.RoutingTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := tree.Routes[1].Routes[0]
+	if !reflect.DeepEqual(dev.GroupBy, []string{"alertname"}) || dev.GroupWait != "1m" {
+		t.Errorf("root.1.0 inherited group_by %v, group_wait %q; want [alertname], 1m", dev.GroupBy, dev.GroupWait)
+	}
+	var trace []string
+	for _, n := range tree.Trace(map[string]string{"team": "db", "env": "dev"}) {
+		trace = append(trace, n.ID)
+	}
+	if want := []string{"root", "root.1", "root.1.0"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("Trace() = %v, want %v", trace, want)
+	}
+}
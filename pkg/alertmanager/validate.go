@@ -0,0 +1,400 @@
+package alertmanager
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ConfigIssue is a problem found while validating a configuration.
+type ConfigIssue struct {
+	Severity string `json:"severity"` // error, warning
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// ConfigChange is a semantic difference between two configurations.
+type ConfigChange struct {
+	Kind   string `json:"kind"`   // receiver, route, inhibit_rule, time_interval, global
+	Action string `json:"action"` // added, removed, modified
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// secretPlaceholder is how Alertmanager masks secrets in the running configuration.
+const secretPlaceholder = "<secret>"
+
+// ValidateConfig checks a configuration for structural problems: unknown or
+// duplicate receivers, invalid matchers and regexes, unknown time intervals
+// and routes that can never be reached. cfg must come from ParseConfig, which
+// already rejects configurations without a top-level route.
+func ValidateConfig(cfg *Config) []ConfigIssue {
+	var issues []ConfigIssue
+	addError := func(path, format string, a ...any) {
+		issues = append(issues, ConfigIssue{Severity: "error", Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+	addWarning := func(path, format string, a ...any) {
+		issues = append(issues, ConfigIssue{Severity: "warning", Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	receivers := make(map[string]bool)
+	for i, r := range cfg.Receivers {
+		path := fmt.Sprintf("receivers[%d]", i)
+		if r.Name == "" {
+			addError(path, "receiver has no name")
+			continue
+		}
+		if receivers[r.Name] {
+			addError(path, "duplicate receiver name %q", r.Name)
+		}
+		receivers[r.Name] = true
+	}
+
+	intervals := make(map[string]bool)
+	for _, ti := range append(append([]TimeInterval{}, cfg.MuteTimeIntervals...), cfg.TimeIntervals...) {
+		if intervals[ti.Name] {
+			addError("time_intervals", "duplicate time interval name %q", ti.Name)
+		}
+		intervals[ti.Name] = true
+	}
+
+	if cfg.Route.Receiver == "" {
+		addError("route", "root route must specify a receiver")
+	}
+	if len(cfg.Route.Match) > 0 || len(cfg.Route.MatchRE) > 0 || len(cfg.Route.Matchers) > 0 {
+		addError("route", "root route must not have any matchers")
+	}
+
+	used := make(map[string]bool)
+	var walk func(r *Route, path string)
+	walk = func(r *Route, path string) {
+		if r.Receiver != "" {
+			used[r.Receiver] = true
+			if !receivers[r.Receiver] {
+				addError(path, "route references unknown receiver %q", r.Receiver)
+			}
+		}
+		if _, err := r.RouteMatchers(); err != nil {
+			addError(path, "%v", err)
+		}
+		for _, name := range append(append([]string{}, r.MuteTimeIntervals...), r.ActiveTimeIntervals...) {
+			if !intervals[name] {
+				addError(path, "route references unknown time interval %q", name)
+			}
+		}
+		catchAll := ""
+		for i, child := range r.Routes {
+			if child == nil {
+				continue
+			}
+			childPath := fmt.Sprintf("%s.routes[%d]", path, i)
+			if catchAll != "" {
+				addWarning(childPath, "route is unreachable: preceded by catch-all route %s without continue", catchAll)
+			}
+			if catchAll == "" && isCatchAll(child) && !child.Continue {
+				catchAll = childPath
+			}
+			walk(child, childPath)
+		}
+	}
+	walk(cfg.Route, "route")
+
+	for i, rule := range cfg.InhibitRules {
+		path := fmt.Sprintf("inhibit_rules[%d]", i)
+		if _, err := rule.SourceMatcherList(); err != nil {
+			addError(path, "source: %v", err)
+		}
+		if _, err := rule.TargetMatcherList(); err != nil {
+			addError(path, "target: %v", err)
+		}
+	}
+
+	for i, r := range cfg.Receivers {
+		if r.Name != "" && !used[r.Name] {
+			addWarning(fmt.Sprintf("receivers[%d]", i), "receiver %q is not referenced by any route", r.Name)
+		}
+	}
+	return issues
+}
+
+func isCatchAll(r *Route) bool {
+	return len(r.Match) == 0 && len(r.MatchRE) == 0 && len(r.Matchers) == 0
+}
+
+// DiffConfigs returns the semantic differences between the current and the
+// proposed configuration. Values masked as <secret> in the current
+// configuration are treated as unchanged.
+func DiffConfigs(current, proposed *Config) []ConfigChange {
+	var changes []ConfigChange
+
+	// Global settings (values are not reported to avoid leaking secrets)
+	for _, key := range unionKeys(current.Global, proposed.Global) {
+		oldVal, inOld := current.Global[key]
+		newVal, inNew := proposed.Global[key]
+		switch {
+		case !inOld:
+			changes = append(changes, ConfigChange{Kind: "global", Action: "added", Name: key})
+		case !inNew:
+			changes = append(changes, ConfigChange{Kind: "global", Action: "removed", Name: key})
+		case !equalIgnoringSecrets(oldVal, newVal):
+			changes = append(changes, ConfigChange{Kind: "global", Action: "modified", Name: key})
+		}
+	}
+
+	// Receivers
+	oldReceivers := receiverMap(current)
+	newReceivers := receiverMap(proposed)
+	for _, name := range unionKeys(oldReceivers, newReceivers) {
+		oldR, inOld := oldReceivers[name]
+		newR, inNew := newReceivers[name]
+		switch {
+		case !inOld:
+			changes = append(changes, ConfigChange{Kind: "receiver", Action: "added", Name: name,
+				Detail: strings.Join(sortedAnyKeys(newR.Integrations), ", ")})
+		case !inNew:
+			changes = append(changes, ConfigChange{Kind: "receiver", Action: "removed", Name: name})
+		default:
+			var modified []string
+			for _, integration := range unionKeys(oldR.Integrations, newR.Integrations) {
+				if !equalIgnoringSecrets(oldR.Integrations[integration], newR.Integrations[integration]) {
+					modified = append(modified, integration)
+				}
+			}
+			if len(modified) > 0 {
+				changes = append(changes, ConfigChange{Kind: "receiver", Action: "modified", Name: name,
+					Detail: "changed: " + strings.Join(modified, ", ")})
+			}
+		}
+	}
+
+	// Routes, keyed by their matcher path from the root
+	oldRoutes, oldChildren := flattenRoutes(current.Route)
+	newRoutes, newChildren := flattenRoutes(proposed.Route)
+	for _, key := range unionKeys(oldRoutes, newRoutes) {
+		oldR, inOld := oldRoutes[key]
+		newR, inNew := newRoutes[key]
+		switch {
+		case !inOld:
+			changes = append(changes, ConfigChange{Kind: "route", Action: "added", Name: key, Detail: "receiver: " + newR.Receiver})
+		case !inNew:
+			changes = append(changes, ConfigChange{Kind: "route", Action: "removed", Name: key, Detail: "receiver: " + oldR.Receiver})
+		default:
+			detail := diffRouteSettings(oldR, newR)
+			if order := diffRouteOrder(oldChildren[key], newChildren[key]); order != "" {
+				detail = strings.TrimPrefix(detail+"; "+order, "; ")
+			}
+			if detail != "" {
+				changes = append(changes, ConfigChange{Kind: "route", Action: "modified", Name: key, Detail: detail})
+			}
+		}
+	}
+
+	// Inhibit rules, compared by their canonical form
+	oldRules := inhibitRuleSet(current)
+	newRules := inhibitRuleSet(proposed)
+	for _, key := range unionKeys(oldRules, newRules) {
+		if !oldRules[key] {
+			changes = append(changes, ConfigChange{Kind: "inhibit_rule", Action: "added", Name: key})
+		} else if !newRules[key] {
+			changes = append(changes, ConfigChange{Kind: "inhibit_rule", Action: "removed", Name: key})
+		}
+	}
+
+	// Time intervals
+	oldIntervals := timeIntervalMap(current)
+	newIntervals := timeIntervalMap(proposed)
+	for _, name := range unionKeys(oldIntervals, newIntervals) {
+		oldTI, inOld := oldIntervals[name]
+		newTI, inNew := newIntervals[name]
+		switch {
+		case !inOld:
+			changes = append(changes, ConfigChange{Kind: "time_interval", Action: "added", Name: name})
+		case !inNew:
+			changes = append(changes, ConfigChange{Kind: "time_interval", Action: "removed", Name: name})
+		case !reflect.DeepEqual(oldTI.TimeIntervals, newTI.TimeIntervals):
+			changes = append(changes, ConfigChange{Kind: "time_interval", Action: "modified", Name: name})
+		}
+	}
+	return changes
+}
+
+// flattenRoutes indexes every route by the chain of matchers leading to it.
+// It also returns the keys of the child routes of every route, in order.
+func flattenRoutes(root *Route) (map[string]*Route, map[string][]string) {
+	routes := make(map[string]*Route)
+	children := make(map[string][]string)
+	var walk func(r *Route, key string) string
+	walk = func(r *Route, key string) string {
+		if _, exists := routes[key]; exists {
+			// Sibling routes with identical matchers: disambiguate by occurrence.
+			for i := 2; ; i++ {
+				candidate := fmt.Sprintf("%s#%d", key, i)
+				if _, exists := routes[candidate]; !exists {
+					key = candidate
+					break
+				}
+			}
+		}
+		routes[key] = r
+		for _, child := range r.Routes {
+			if child == nil {
+				continue
+			}
+			label := "{" + strings.Join(child.Matchers, ", ") + "}"
+			if matchers, err := child.RouteMatchers(); err == nil {
+				label = FormatMatchers(matchers)
+			}
+			children[key] = append(children[key], walk(child, key+" > "+label))
+		}
+		return key
+	}
+	if root != nil {
+		walk(root, "root")
+	}
+	return routes, children
+}
+
+// diffRouteOrder describes a change in the order of the child routes present
+// in both configurations. Alerts go to the first matching child route unless
+// it has continue set, so reordering siblings can re-route alerts.
+func diffRouteOrder(oldChildren, newChildren []string) string {
+	oldOrder := slices.DeleteFunc(slices.Clone(oldChildren), func(k string) bool { return !slices.Contains(newChildren, k) })
+	newOrder := slices.DeleteFunc(slices.Clone(newChildren), func(k string) bool { return !slices.Contains(oldChildren, k) })
+	if slices.Equal(oldOrder, newOrder) {
+		return ""
+	}
+	return fmt.Sprintf("child route order %s → %s", childLabels(oldOrder), childLabels(newOrder))
+}
+
+// childLabels lists child route keys by their last matcher set.
+func childLabels(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = k[strings.LastIndex(k, " > ")+len(" > "):]
+	}
+	return "[" + strings.Join(labels, ", ") + "]"
+}
+
+func diffRouteSettings(oldR, newR *Route) string {
+	var diffs []string
+	if oldR.Receiver != newR.Receiver {
+		diffs = append(diffs, fmt.Sprintf("receiver %q → %q", oldR.Receiver, newR.Receiver))
+	}
+	if !reflect.DeepEqual(oldR.GroupBy, newR.GroupBy) {
+		diffs = append(diffs, fmt.Sprintf("group_by %v → %v", oldR.GroupBy, newR.GroupBy))
+	}
+	if oldR.Continue != newR.Continue {
+		diffs = append(diffs, fmt.Sprintf("continue %t → %t", oldR.Continue, newR.Continue))
+	}
+	if oldR.GroupWait != newR.GroupWait {
+		diffs = append(diffs, fmt.Sprintf("group_wait %q → %q", oldR.GroupWait, newR.GroupWait))
+	}
+	if oldR.GroupInterval != newR.GroupInterval {
+		diffs = append(diffs, fmt.Sprintf("group_interval %q → %q", oldR.GroupInterval, newR.GroupInterval))
+	}
+	if oldR.RepeatInterval != newR.RepeatInterval {
+		diffs = append(diffs, fmt.Sprintf("repeat_interval %q → %q", oldR.RepeatInterval, newR.RepeatInterval))
+	}
+	if !reflect.DeepEqual(oldR.MuteTimeIntervals, newR.MuteTimeIntervals) {
+		diffs = append(diffs, fmt.Sprintf("mute_time_intervals %v → %v", oldR.MuteTimeIntervals, newR.MuteTimeIntervals))
+	}
+	if !reflect.DeepEqual(oldR.ActiveTimeIntervals, newR.ActiveTimeIntervals) {
+		diffs = append(diffs, fmt.Sprintf("active_time_intervals %v → %v", oldR.ActiveTimeIntervals, newR.ActiveTimeIntervals))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+func inhibitRuleSet(cfg *Config) map[string]bool {
+	rules := make(map[string]bool)
+	for _, rule := range cfg.InhibitRules {
+		source, errS := rule.SourceMatcherList()
+		target, errT := rule.TargetMatcherList()
+		if errS != nil || errT != nil {
+			continue
+		}
+		equal := append([]string{}, rule.Equal...)
+		sort.Strings(equal)
+		key := fmt.Sprintf("source %s → target %s equal [%s]",
+			FormatMatchers(source), FormatMatchers(target), strings.Join(equal, ", "))
+		rules[key] = true
+	}
+	return rules
+}
+
+func receiverMap(cfg *Config) map[string]ReceiverConfig {
+	m := make(map[string]ReceiverConfig, len(cfg.Receivers))
+	for _, r := range cfg.Receivers {
+		m[r.Name] = r
+	}
+	return m
+}
+
+func timeIntervalMap(cfg *Config) map[string]TimeInterval {
+	m := make(map[string]TimeInterval)
+	for _, ti := range append(append([]TimeInterval{}, cfg.MuteTimeIntervals...), cfg.TimeIntervals...) {
+		m[ti.Name] = ti
+	}
+	return m
+}
+
+// equalIgnoringSecrets compares two decoded YAML values, treating any
+// <secret> placeholder in the current value as equal to the proposed one.
+func equalIgnoringSecrets(current, proposed any) bool {
+	if s, ok := current.(string); ok && s == secretPlaceholder {
+		return true
+	}
+	switch c := current.(type) {
+	case map[string]any:
+		p, ok := proposed.(map[string]any)
+		if !ok || len(c) != len(p) {
+			return false
+		}
+		for k, v := range c {
+			pv, ok := p[k]
+			if !ok || !equalIgnoringSecrets(v, pv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		p, ok := proposed.([]any)
+		if !ok || len(c) != len(p) {
+			return false
+		}
+		for i := range c {
+			if !equalIgnoringSecrets(c[i], p[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(current, proposed)
+	}
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedAnyKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package alertmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+const baseConfig = `
+route:
+  receiver: default
+  routes:
+  - receiver: db
+    matchers: ['team="db"']
+  - receiver: web
+    matchers: ['team="web"']
+receivers:
+- name: default
+- name: db
+  webhook_configs:
+  - url: http://db
+- name: web
+  webhook_configs:
+  - url: http://web
+`
+
+func TestParseConfigRejectsUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{"top level", "route:\n  receiver: a\nrecievers:\n- name: a\n", `unknown field "recievers"`},
+		{"route", "route:\n  recevier: a\nreceivers:\n- name: a\n", `unknown field "recevier"`},
+		{"receiver", "route:\n  receiver: a\nreceivers:\n- name: a\n  webhook_config: []\n", `unknown field "webhook_config"`},
+		{"no route", "receivers:\n- name: a\n", "no top-level route"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseConfig() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{"valid", baseConfig, nil},
+		{"unknown receiver", "route:\n  receiver: missing\nreceivers:\n- name: a\n", []string{
+			`route: route references unknown receiver "missing"`,
+			`receivers[0]: receiver "a" is not referenced by any route`,
+		}},
+		{"unreachable", "route:\n  receiver: a\n  routes:\n  - receiver: a\n  - receiver: a\n    matchers: ['x=\"y\"']\nreceivers:\n- name: a\n", []string{
+			"route.routes[1]: route is unreachable: preceded by catch-all route route.routes[0] without continue",
+		}},
+		{"bad matcher", "route:\n  receiver: a\n  routes:\n  - receiver: a\n    matchers: ['x=~\"(\"']\nreceivers:\n- name: a\n", []string{
+			"route.routes[0]: ",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ParseConfig(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			issues := ValidateConfig(cfg)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("ValidateConfig() = %v, want %d issues", issues, len(tt.want))
+			}
+			for i, issue := range issues {
+				if got := issue.Path + ": " + issue.Message; !strings.HasPrefix(got, tt.want[i]) {
+					t.Errorf("issue %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposed string
+		want     []string
+	}{
+		{"unchanged", baseConfig, nil},
+		{"reordered", `
+route:
+  receiver: default
+  routes:
+  - receiver: web
+    matchers: ['team="web"']
+  - receiver: db
+    matchers: ['team="db"']
+receivers:
+- name: default
+- name: db
+  webhook_configs:
+  - url: http://db
+- name: web
+  webhook_configs:
+  - url: http://web
+`, []string{`route modified root: child route order [{team="db"}, {team="web"}] → [{team="web"}, {team="db"}]`}},
+		{"receiver changed", strings.Replace(baseConfig, "http://web", "http://web2", 1), []string{"receiver modified web: changed: webhook_configs"}},
+	}
+	current, err := ParseConfig(baseConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proposed, err := ParseConfig(tt.proposed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range DiffConfigs(current, proposed) {
+				got = append(got, strings.TrimSuffix(c.Kind+" "+c.Action+" "+c.Name+": "+c.Detail, ": "))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("DiffConfigs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
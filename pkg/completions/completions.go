@@ -0,0 +1,152 @@
+// Package completions completes prompt arguments and resource template
+// variables from live Alertmanager data.
+package completions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// maxValues is the maximum number of values in a completion result, as
+// defined by the MCP specification.
+const maxValues = 100
+
+// durations are suggested for duration-like arguments.
+var durations = []string{"30m", "1h", "2h", "4h", "8h", "12h", "1d"}
+
+// candidate is a completion value with extra text it can be found by.
+type candidate struct {
+	value string
+	// detail is also searched, e.g. a silence comment or an alert name.
+	detail string
+}
+
+// Handler returns the server's completion handler. Completions are chosen by
+// argument name, so they work for every prompt and resource template:
+//
+//   - alertName: alert names of current alerts
+//   - receiver: receiver names
+//   - id, silenceId: silence IDs, also found by their comment
+//   - fingerprint: alert fingerprints, also found by alert name
+//   - duration, shift: common durations
+//   - any other name: values of the alert label with that name
+func Handler(client *alertmanager.Client) func(context.Context, *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return func(ctx context.Context, request *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		arg := request.Params.Argument
+		candidates, err := candidates(client, arg.Name)
+		if err != nil {
+			return nil, err
+		}
+		return complete(candidates, arg.Value), nil
+	}
+}
+
+func candidates(client *alertmanager.Client, name string) ([]candidate, error) {
+	switch name {
+	case "alertName":
+		return labelValues(client, "alertname")
+	case "receiver":
+		receivers, err := client.GetReceivers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receivers: %w", err)
+		}
+		var out []candidate
+		for _, r := range receivers {
+			out = append(out, candidate{value: r.Name})
+		}
+		return out, nil
+	case "id", "silenceId":
+		silences, err := client.GetSilences("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get silences: %w", err)
+		}
+		// Live silences first, newest first within each group.
+		output.SortSilences(silences, "-updatedAt")
+		sort.SliceStable(silences, func(i, j int) bool {
+			return silences[i].Status.State != "expired" && silences[j].Status.State == "expired"
+		})
+		var out []candidate
+		for _, s := range silences {
+			out = append(out, candidate{value: s.ID, detail: s.Comment})
+		}
+		return out, nil
+	case "fingerprint":
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		output.SortAlerts(alerts, "alertname")
+		var out []candidate
+		for _, a := range alerts {
+			out = append(out, candidate{value: a.Fingerprint, detail: a.Labels["alertname"]})
+		}
+		return out, nil
+	case "duration", "shift":
+		var out []candidate
+		for _, d := range durations {
+			out = append(out, candidate{value: d})
+		}
+		return out, nil
+	default:
+		return labelValues(client, name)
+	}
+}
+
+// labelValues returns the distinct values of a label across current alerts,
+// unless the label is hidden by the server's label filter.
+func labelValues(client *alertmanager.Client, label string) ([]candidate, error) {
+	if !output.LabelVisible(label, nil) {
+		return nil, nil
+	}
+	alerts, err := client.GetAlertsRaw("true", "true", "true")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	seen := make(map[string]bool)
+	var values []string
+	for _, a := range alerts {
+		if v := a.Labels[label]; v != "" && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	out := make([]candidate, len(values))
+	for i, v := range values {
+		out[i] = candidate{value: v}
+	}
+	return out, nil
+}
+
+// complete returns the candidates matching the typed prefix: values starting
+// with it first, then values or details containing it, case-insensitively.
+func complete(candidates []candidate, typed string) *mcp.CompleteResult {
+	typed = strings.ToLower(typed)
+	var prefixed, contained []string
+	for _, c := range candidates {
+		value := strings.ToLower(c.value)
+		switch {
+		case strings.HasPrefix(value, typed):
+			prefixed = append(prefixed, c.value)
+		case strings.Contains(value, typed) || strings.Contains(strings.ToLower(c.detail), typed):
+			contained = append(contained, c.value)
+		}
+	}
+	values := append(prefixed, contained...)
+	result := &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: values, Total: len(values)}}
+	if len(values) > maxValues {
+		result.Completion.Values = values[:maxValues]
+		result.Completion.HasMore = true
+	}
+	if result.Completion.Values == nil {
+		result.Completion.Values = []string{}
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package history
+
+import (
+	"context"
+	"time"
+)
+
+// Source answers alert history queries. It is implemented by the history
+// file Store and by the Prometheus ALERTS history.
+type Source interface {
+	// Name describes where the history comes from, for tool output.
+	Name() string
+	// Occurrences returns the occurrences of an alert that were firing at some
+	// point since the given time, ordered by start time. An empty alert name
+	// matches every alert.
+	Occurrences(ctx context.Context, alertName string, since time.Time) ([]Occurrence, error)
+	// RecordedSince returns when the history starts, or the zero time if
+	// unknown.
+	RecordedSince() time.Time
+}
+
+// Name implements Source.
+func (s *Store) Name() string {
+	return "history file " + s.path
+}
+
+// Occurrences implements Source.
+func (s *Store) Occurrences(ctx context.Context, alertName string, since time.Time) ([]Occurrence, error) {
+	return s.Query(alertName, since), nil
+}
+
+// RecordedSince implements Source. It is when the store started recording,
+// or the start of the retention period if occurrences expired since.
+func (s *Store) RecordedSince() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recordingSince
+}
@@ -0,0 +1,45 @@
+package history
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ByFingerprint groups occurrences by fingerprint, each group ordered by
+// start time.
+func ByFingerprint(occurrences []Occurrence) map[string][]Occurrence {
+	groups := make(map[string][]Occurrence)
+	for _, o := range occurrences {
+		groups[o.Fingerprint] = append(groups[o.Fingerprint], o)
+	}
+	for _, g := range groups {
+		sort.SliceStable(g, func(i, j int) bool { return g[i].StartsAt.Before(g[j].StartsAt) })
+	}
+	return groups
+}
+
+// Percentile returns the p-th percentile (0-100) of durations by the
+// nearest-rank method, or zero if there are none.
+func Percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// Gaps returns the time between the end of each occurrence and the start of
+// the next one, for occurrences of one fingerprint ordered by start time.
+func Gaps(occurrences []Occurrence) []time.Duration {
+	var gaps []time.Duration
+	for i := 1; i < len(occurrences); i++ {
+		if prev := occurrences[i-1].EndsAt; prev != nil {
+			gaps = append(gaps, max(occurrences[i].StartsAt.Sub(*prev), 0))
+		}
+	}
+	return gaps
+}
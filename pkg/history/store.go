@@ -0,0 +1,350 @@
+// Package history records alert occurrences in a local file, so alert history
+// outlives Alertmanager, which only keeps current alerts.
+//
+// The file holds one JSON record per line. Every change to an occurrence
+// appends its new version; the last version of each occurrence wins when the
+// file is loaded. The file is rewritten without superseded versions and
+// expired occurrences when it grows.
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/watcher"
+)
+
+// DefaultRetention is how long resolved occurrences are kept by default.
+const DefaultRetention = 30 * 24 * time.Hour
+
+// Resolved is the state recorded when an alert disappears from Alertmanager.
+const Resolved = "resolved"
+
+// Transition is a change of an alert's state, or of what suppresses it.
+type Transition struct {
+	Time        time.Time `json:"time"`
+	State       string    `json:"state" jsonschema:"active, suppressed or resolved; pending or firing when reconstructed from Prometheus"`
+	SilencedBy  []string  `json:"silencedBy,omitempty"`
+	InhibitedBy []string  `json:"inhibitedBy,omitempty"`
+}
+
+// Notification is a webhook notification Alertmanager sent about an alert.
+type Notification struct {
+	Time     time.Time `json:"time" jsonschema:"when the notification was received"`
+	Receiver string    `json:"receiver"`
+	Status   string    `json:"status" jsonschema:"firing or resolved"`
+	GroupKey string    `json:"groupKey"`
+}
+
+// Occurrence is one firing of an alert, from its start to its resolution.
+type Occurrence struct {
+	Fingerprint   string            `json:"fingerprint"`
+	Labels        map[string]string `json:"labels"`
+	StartsAt      time.Time         `json:"startsAt"`
+	EndsAt        *time.Time        `json:"endsAt,omitempty" jsonschema:"resolution time; absent while the alert is firing"`
+	Transitions   []Transition      `json:"transitions"`
+	Notifications []Notification    `json:"notifications,omitempty" jsonschema:"notifications received by the webhook endpoint"`
+}
+
+// Open reports whether the occurrence is still firing.
+func (o *Occurrence) Open() bool {
+	return o.EndsAt == nil
+}
+
+// Duration returns how long the occurrence lasted, or has lasted so far.
+func (o *Occurrence) Duration(now time.Time) time.Duration {
+	if o.EndsAt != nil {
+		return o.EndsAt.Sub(o.StartsAt)
+	}
+	return now.Sub(o.StartsAt)
+}
+
+// Silenced reports whether the occurrence was ever silenced.
+func (o *Occurrence) Silenced() bool {
+	return slices.ContainsFunc(o.Transitions, func(t Transition) bool { return len(t.SilencedBy) > 0 })
+}
+
+// record is one line of the history file.
+type record struct {
+	RecordingSince *time.Time  `json:"recordingSince,omitempty"`
+	Occurrence     *Occurrence `json:"occurrence,omitempty"`
+}
+
+// Store is the alert history, kept in memory and persisted to a file.
+type Store struct {
+	path      string
+	retention time.Duration
+
+	mu             sync.Mutex
+	recordingSince time.Time
+	occurrences    map[string]*Occurrence // by fingerprint and start
+	open           map[string]*Occurrence // by fingerprint
+	lines          int
+}
+
+// Open loads the history file at path, creating it if needed. Occurrences
+// resolved more than retention ago are dropped.
+func Open(path string, retention time.Duration) (*Store, error) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	s := &Store{
+		path:        path,
+		retention:   retention,
+		occurrences: make(map[string]*Occurrence),
+		open:        make(map[string]*Occurrence),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("loading alert history %s: %w", path, err)
+	}
+	if s.recordingSince.IsZero() {
+		s.recordingSince = time.Now()
+	}
+	s.prune(time.Now())
+	if err := s.compact(); err != nil {
+		return nil, fmt.Errorf("writing alert history %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *Store) load() error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// A crash can leave a partial last line; skip it rather than lose the history.
+			klog.Warningf("Skipping invalid alert history record at %s:%d: %v", s.path, line, err)
+			continue
+		}
+		if r.RecordingSince != nil {
+			s.recordingSince = *r.RecordingSince
+		}
+		if o := r.Occurrence; o != nil {
+			s.occurrences[key(o)] = o
+		}
+		s.lines++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, o := range s.occurrences {
+		if prev := s.open[o.Fingerprint]; o.Open() && (prev == nil || o.StartsAt.After(prev.StartsAt)) {
+			s.open[o.Fingerprint] = o
+		}
+	}
+	return nil
+}
+
+// Retention returns how long resolved occurrences are kept.
+func (s *Store) Retention() time.Duration {
+	return s.retention
+}
+
+// Update is a watcher handler that records the alerts of every poll.
+func (s *Store) Update(ctx context.Context, snapshot *watcher.Snapshot, events []watcher.Event) {
+	if err := s.Record(snapshot.Time, snapshot.Alerts); err != nil {
+		klog.Warningf("Recording alert history failed: %v", err)
+	}
+}
+
+// Record updates the history with the alerts seen at time now, keyed by
+// fingerprint. New alerts start an occurrence, changed states and silences
+// add a transition, and open occurrences whose alert is gone are resolved.
+func (s *Store) Record(now time.Time, alerts map[string]alertmanager.GettableAlert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []*Occurrence
+	for fp, a := range alerts {
+		o := s.open[fp]
+		if o != nil && !o.StartsAt.Equal(a.StartsAt) {
+			// The alert resolved and fired again between two polls.
+			resolve(o, a.StartsAt)
+			changed = append(changed, o)
+			o = nil
+		}
+		t := Transition{Time: now, State: a.Status.State, SilencedBy: a.Status.SilencedBy, InhibitedBy: a.Status.InhibitedBy}
+		if o == nil {
+			if existing := s.occurrences[occurrenceKey(fp, a.StartsAt)]; existing != nil {
+				// Already resolved by a webhook notification.
+				continue
+			}
+			o = &Occurrence{Fingerprint: fp, Labels: a.Labels, StartsAt: a.StartsAt}
+			t.Time = a.StartsAt
+			o.Transitions = []Transition{t}
+			s.occurrences[key(o)] = o
+			s.open[fp] = o
+			changed = append(changed, o)
+			continue
+		}
+		last := o.Transitions[len(o.Transitions)-1]
+		if last.State != t.State || !slices.Equal(last.SilencedBy, t.SilencedBy) || !slices.Equal(last.InhibitedBy, t.InhibitedBy) {
+			o.Transitions = append(o.Transitions, t)
+			changed = append(changed, o)
+		}
+	}
+	for fp, o := range s.open {
+		if _, ok := alerts[fp]; !ok {
+			resolve(o, now)
+			delete(s.open, fp)
+			changed = append(changed, o)
+		}
+	}
+
+	return s.save(now, changed)
+}
+
+// save persists changed occurrences, rewriting the file instead when
+// occurrences expired or it holds too many superseded versions.
+func (s *Store) save(now time.Time, changed []*Occurrence) error {
+	if s.prune(now) || s.lines > 2*len(s.occurrences)+1000 {
+		return s.compact()
+	}
+	return s.append(changed)
+}
+
+func resolve(o *Occurrence, at time.Time) {
+	o.EndsAt = &at
+	o.Transitions = append(o.Transitions, Transition{Time: at, State: Resolved})
+}
+
+// prune drops occurrences resolved before the retention period and reports
+// whether any were dropped.
+func (s *Store) prune(now time.Time) bool {
+	cutoff := now.Add(-s.retention)
+	pruned := false
+	for k, o := range s.occurrences {
+		if o.EndsAt != nil && o.EndsAt.Before(cutoff) {
+			delete(s.occurrences, k)
+			pruned = true
+		}
+	}
+	if cutoff.After(s.recordingSince) && pruned {
+		s.recordingSince = cutoff
+	}
+	return pruned
+}
+
+// append writes the new versions of changed occurrences to the file.
+func (s *Store) append(changed []*Occurrence) error {
+	if len(changed) == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, o := range changed {
+		if err := enc.Encode(record{Occurrence: o}); err != nil {
+			f.Close()
+			return err
+		}
+		s.lines++
+	}
+	return f.Close()
+}
+
+// compact rewrites the file with the current version of every occurrence.
+// The new file replaces the old one atomically.
+func (s *Store) compact() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	since := s.recordingSince
+	if err := enc.Encode(record{RecordingSince: &since}); err != nil {
+		tmp.Close()
+		return err
+	}
+	lines := 1
+	for _, o := range s.sorted() {
+		if err := enc.Encode(record{Occurrence: o}); err != nil {
+			tmp.Close()
+			return err
+		}
+		lines++
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	s.lines = lines
+	return nil
+}
+
+// sorted returns the occurrences ordered by start time.
+func (s *Store) sorted() []*Occurrence {
+	out := make([]*Occurrence, 0, len(s.occurrences))
+	for _, o := range s.occurrences {
+		out = append(out, o)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].StartsAt.Equal(out[j].StartsAt) {
+			return out[i].StartsAt.Before(out[j].StartsAt)
+		}
+		return out[i].Fingerprint < out[j].Fingerprint
+	})
+	return out
+}
+
+// Query returns copies of the occurrences of an alert that were firing at
+// some point since the given time, ordered by start time. An empty alert name
+// matches every alert.
+func (s *Store) Query(alertName string, since time.Time) []Occurrence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Occurrence
+	for _, o := range s.sorted() {
+		if alertName != "" && o.Labels["alertname"] != alertName {
+			continue
+		}
+		if o.EndsAt != nil && o.EndsAt.Before(since) {
+			continue
+		}
+		c := *o
+		c.Transitions = slices.Clone(o.Transitions)
+		out = append(out, c)
+	}
+	return out
+}
+
+func key(o *Occurrence) string {
+	return occurrenceKey(o.Fingerprint, o.StartsAt)
+}
+
+func occurrenceKey(fingerprint string, startsAt time.Time) string {
+	return fingerprint + "@" + startsAt.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,140 @@
+package history
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+func alert(fp, state string, startsAt time.Time, silencedBy ...string) alertmanager.GettableAlert {
+	a := alertmanager.GettableAlert{Fingerprint: fp, StartsAt: startsAt}
+	a.Labels = map[string]string{"alertname": "DiskFull", "instance": fp}
+	a.Status.State = state
+	a.Status.SilencedBy = silencedBy
+	return a
+}
+
+func alerts(list ...alertmanager.GettableAlert) map[string]alertmanager.GettableAlert {
+	m := make(map[string]alertmanager.GettableAlert)
+	for _, a := range list {
+		m[a.Fingerprint] = a
+	}
+	return m
+}
+
+func lines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+func TestRecord(t *testing.T) {
+	start := time.Now().Add(-10 * time.Hour).Truncate(time.Second)
+	refired := start.Add(90 * time.Minute)
+	tests := []struct {
+		name  string
+		polls []map[string]alertmanager.GettableAlert
+		want  []string // states of the transitions of each occurrence
+	}{
+		{"firing", []map[string]alertmanager.GettableAlert{
+			alerts(alert("a", "active", start)),
+			alerts(alert("a", "active", start)),
+		}, []string{"active"}},
+		{"silenced and resolved", []map[string]alertmanager.GettableAlert{
+			alerts(alert("a", "active", start)),
+			alerts(alert("a", "suppressed", start, "s1")),
+			alerts(),
+		}, []string{"active suppressed resolved"}},
+		{"refired between polls", []map[string]alertmanager.GettableAlert{
+			alerts(alert("a", "active", start)),
+			alerts(alert("a", "active", refired)),
+		}, []string{"active resolved", "active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history.jsonl")
+			s, err := Open(path, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, poll := range tt.polls {
+				if err := s.Record(start.Add(time.Duration(i+1)*time.Hour), poll); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// The occurrences must survive reopening the file.
+			reopened, err := Open(path, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for name, store := range map[string]*Store{"recorded": s, "reopened": reopened} {
+				got := store.Query("DiskFull", time.Time{})
+				if len(got) != len(tt.want) {
+					t.Fatalf("%s: Query() returned %d occurrences, want %d", name, len(got), len(tt.want))
+				}
+				for i, o := range got {
+					var states []string
+					for _, tr := range o.Transitions {
+						states = append(states, tr.State)
+					}
+					if strings.Join(states, " ") != tt.want[i] {
+						t.Errorf("%s: occurrence %d transitions = %q, want %q", name, i, states, tt.want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOpenCompacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "history.jsonl")
+	s, err := Open(path, 48*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	expired := now.Add(-72 * time.Hour)
+	recent := now.Add(-time.Hour)
+	for _, poll := range []struct {
+		at     time.Time
+		alerts map[string]alertmanager.GettableAlert
+	}{
+		{expired, alerts(alert("old", "active", expired))},
+		{expired.Add(time.Hour), alerts()},
+		{recent, alerts(alert("new", "active", recent))},
+		{recent.Add(time.Minute), alerts(alert("new", "suppressed", recent, "s1"))},
+	} {
+		if err := s.Record(poll.at, poll.alerts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A crash can leave a partial last line, which is skipped.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"occurrence":{"finger`)
+	f.Close()
+
+	s, err = Open(path, 48*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Query("", time.Time{})
+	if len(got) != 1 || got[0].Fingerprint != "new" || len(got[0].Transitions) != 2 {
+		t.Fatalf("Query() = %+v, want the open occurrence of new with 2 transitions", got)
+	}
+	// The compacted file holds the recordingSince line and one line per occurrence.
+	if n := lines(t, path); n != 2 {
+		t.Errorf("compacted file has %d lines, want 2", n)
+	}
+}
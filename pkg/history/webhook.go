@@ -0,0 +1,103 @@
+package history
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+// maxWebhookBytes bounds the size of a webhook payload.
+const maxWebhookBytes = 10 << 20
+
+// RecordNotification records a webhook notification received at time at. Each
+// alert's occurrence gets the notification, starting the occurrence if it is
+// not known yet; resolved alerts close it at their exact resolution time.
+func (s *Store) RecordNotification(msg *alertmanager.WebhookMessage, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changed []*Occurrence
+	for _, a := range msg.Alerts {
+		fp := a.Fingerprint
+		if fp == "" {
+			fp = alertmanager.Fingerprint(a.Labels)
+		}
+		o := s.occurrences[occurrenceKey(fp, a.StartsAt)]
+		if o == nil {
+			if prev := s.open[fp]; prev != nil {
+				// The previous occurrence resolved without us noticing.
+				resolve(prev, a.StartsAt)
+				changed = append(changed, prev)
+			}
+			o = &Occurrence{
+				Fingerprint: fp,
+				Labels:      a.Labels,
+				StartsAt:    a.StartsAt,
+				Transitions: []Transition{{Time: a.StartsAt, State: "active"}},
+			}
+			s.occurrences[key(o)] = o
+			s.open[fp] = o
+		}
+		if a.Status == "resolved" && !a.EndsAt.IsZero() {
+			if o.Open() {
+				resolve(o, a.EndsAt)
+			} else if last := &o.Transitions[len(o.Transitions)-1]; last.State == Resolved {
+				// Polling noticed the resolution later than it happened.
+				end := a.EndsAt
+				o.EndsAt = &end
+				last.Time = end
+			}
+			if s.open[fp] == o {
+				delete(s.open, fp)
+			}
+		}
+		o.Notifications = append(o.Notifications, Notification{
+			Time:     at,
+			Receiver: msg.Receiver,
+			Status:   a.Status,
+			GroupKey: msg.GroupKey,
+		})
+		changed = append(changed, o)
+	}
+	return s.save(at, changed)
+}
+
+// WebhookHandler returns an HTTP handler accepting Alertmanager webhook
+// notifications. Requests must carry token as a bearer token; with an empty
+// token every request is rejected.
+func (s *Store) WebhookHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		want := "Bearer " + token
+		if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(want)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var msg alertmanager.WebhookMessage
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBytes)).Decode(&msg); err != nil {
+			http.Error(w, fmt.Sprintf("invalid webhook payload: %v", err), http.StatusBadRequest)
+			return
+		}
+		if msg.Receiver == "" || len(msg.Alerts) == 0 {
+			http.Error(w, "invalid webhook payload: receiver and alerts are required", http.StatusBadRequest)
+			return
+		}
+		if err := s.RecordNotification(&msg, time.Now()); err != nil {
+			klog.Warningf("Recording webhook notification failed: %v", err)
+			http.Error(w, "failed to record notification", http.StatusInternalServerError)
+			return
+		}
+		klog.V(2).Infof("Recorded %s notification for receiver %s with %d alerts", msg.Status, msg.Receiver, len(msg.Alerts))
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	nodesGVR = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	podsGVR  = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// Cluster looks up the Kubernetes objects alerts refer to.
+type Cluster struct {
+	client dynamic.Interface
+}
+
+// NewCluster creates a Cluster from the given kubeconfig, in-cluster config or
+// default kubeconfig rules. Requires permission to get nodes and list pods.
+func NewCluster(kubeconfig string) (*Cluster, error) {
+	config, err := getRESTConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes config: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+	return &Cluster{client: client}, nil
+}
+
+// Node is a node with the addresses and pods alerts about it are labelled with.
+type Node struct {
+	Name string
+	// Addresses are the IP addresses and host names of the node.
+	Addresses []string
+	// Pods are the pods scheduled on the node, sorted by namespace and name.
+	Pods []Pod
+}
+
+// Pod identifies a pod.
+type Pod struct {
+	Namespace string
+	Name      string
+}
+
+// Node returns the node with the given name and the pods running on it.
+func (c *Cluster) Node(ctx context.Context, name string) (*Node, error) {
+	obj, err := c.client.Resource(nodesGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting node %s: %w", name, err)
+	}
+	node := &Node{Name: name}
+	addresses, _, _ := unstructured.NestedSlice(obj.Object, "status", "addresses")
+	for _, a := range addresses {
+		address, _ := a.(map[string]any)["address"].(string)
+		if address != "" && address != name && !slices.Contains(node.Addresses, address) {
+			node.Addresses = append(node.Addresses, address)
+		}
+	}
+
+	pods, err := c.client.Resource(podsGVR).List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + name})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods on node %s: %w", name, err)
+	}
+	for _, p := range pods.Items {
+		node.Pods = append(node.Pods, Pod{Namespace: p.GetNamespace(), Name: p.GetName()})
+	}
+	slices.SortFunc(node.Pods, func(a, b Pod) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return node, nil
+}
@@ -1,26 +1,12 @@
 package mcputil
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// GetArguments extracts the tool call arguments from an MCP CallToolRequest.
-func GetArguments(request *mcp.CallToolRequest) (map[string]any, error) {
-	params, ok := request.GetParams().(*mcp.CallToolParamsRaw)
-	if !ok {
-		return nil, errors.New("invalid tool call parameters")
-	}
-	var args map[string]any
-	if err := json.Unmarshal(params.Arguments, &args); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
-	}
-	return args, nil
-}
-
 // NewTextResult creates a successful text result.
 func NewTextResult(text string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
@@ -39,3 +25,25 @@ func NewErrorResult(text string) *mcp.CallToolResult {
 		},
 	}
 }
+
+// NewStructuredResult creates a successful result carrying both a text
+// rendering and the structured content it was rendered from.
+func NewStructuredResult(text string, data any) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+		StructuredContent: data,
+	}
+}
+
+// OutputSchema returns the tool output schema inferred from T, which must be
+// a struct type. It panics on unsupported types, like mcp.Server.AddTool does
+// for invalid schemas.
+func OutputSchema[T any]() *jsonschema.Schema {
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		panic(fmt.Errorf("output schema: %w", err))
+	}
+	return schema
+}
@@ -0,0 +1,212 @@
+package mcputil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	typeSchemasMu sync.RWMutex
+	typeSchemas   = make(map[reflect.Type]*jsonschema.Schema)
+)
+
+// SetTypeSchema sets the schema inferred for argument fields of type T, e.g.
+// to restrict a named string type to an enum. The description of a field's
+// jsonschema tag still takes precedence.
+func SetTypeSchema[T any](schema *jsonschema.Schema) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	typeSchemas[reflect.TypeFor[T]()] = schema
+}
+
+// Input binds tool call arguments to the struct type T. The tool's input
+// schema is inferred from T: JSON names come from json tags, descriptions from
+// jsonschema tags, and fields without omitempty are required.
+type Input[T any] struct {
+	schema     *jsonschema.Schema
+	properties map[string]*jsonschema.Resolved
+}
+
+// NewInput infers the input schema of T and applies the refine functions to
+// it, e.g. Enum or Minimum. It panics if the schema is invalid, like
+// mcp.Server.AddTool does.
+func NewInput[T any](refine ...func(*jsonschema.Schema)) *Input[T] {
+	typeSchemasMu.RLock()
+	schema, err := jsonschema.For[T](&jsonschema.ForOptions{TypeSchemas: typeSchemas})
+	typeSchemasMu.RUnlock()
+	if err != nil {
+		panic(fmt.Errorf("input schema: %w", err))
+	}
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	for _, fn := range refine {
+		fn(schema)
+	}
+	in := &Input[T]{schema: schema, properties: make(map[string]*jsonschema.Resolved)}
+	for name, prop := range schema.Properties {
+		resolved, err := prop.Resolve(nil)
+		if err != nil {
+			panic(fmt.Errorf("input schema property %q: %w", name, err))
+		}
+		in.properties[name] = resolved
+	}
+	return in
+}
+
+// Schema returns the inferred input schema.
+func (in *Input[T]) Schema() *jsonschema.Schema {
+	return in.schema
+}
+
+// Bind validates the call arguments against the input schema and decodes
+// them into T. Errors name the offending argument so the caller can fix it.
+func (in *Input[T]) Bind(request *mcp.CallToolRequest) (T, error) {
+	var args T
+	params, ok := request.GetParams().(*mcp.CallToolParamsRaw)
+	if !ok {
+		return args, errors.New("invalid tool call parameters")
+	}
+	raw := params.Arguments
+	if len(raw) == 0 || string(raw) == "null" {
+		raw = json.RawMessage("{}")
+	}
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return args, fmt.Errorf("arguments must be a JSON object: %w", err)
+	}
+	if err := in.validate(values); err != nil {
+		return args, err
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return args, fmt.Errorf("failed to decode arguments: %w", err)
+	}
+	return args, nil
+}
+
+func (in *Input[T]) validate(values map[string]any) error {
+	var missing []string
+	for _, name := range in.schema.Required {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required argument(s): %s", strings.Join(missing, ", "))
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := in.properties[name]
+		if !ok {
+			return fmt.Errorf("unknown argument %q; valid arguments are: %s", name, strings.Join(in.argumentNames(), ", "))
+		}
+		if err := prop.Validate(values[name]); err != nil {
+			return fmt.Errorf("invalid argument %q: %s", name, describe(in.schema.Properties[name], values[name], err))
+		}
+	}
+	return nil
+}
+
+func (in *Input[T]) argumentNames() []string {
+	names := make([]string, 0, len(in.schema.Properties))
+	for name := range in.schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// describe turns a validation error into a message stating what was expected.
+func describe(schema *jsonschema.Schema, value any, err error) string {
+	got, _ := json.Marshal(value)
+	switch {
+	case len(schema.Enum) > 0:
+		allowed := make([]string, len(schema.Enum))
+		for i, v := range schema.Enum {
+			allowed[i] = fmt.Sprint(v)
+		}
+		return fmt.Sprintf("got %s, must be one of: %s", got, strings.Join(allowed, ", "))
+	case schema.Type != "" && !matchesType(schema.Type, value):
+		return fmt.Sprintf("got %s, must be %s", got, article(schema.Type))
+	case len(schema.Types) > 0 && !slices.ContainsFunc(schema.Types, func(t string) bool { return matchesType(t, value) }):
+		// Optional fields accept null; naming it would only confuse the caller.
+		types := slices.DeleteFunc(slices.Clone(schema.Types), func(t string) bool { return t == "null" })
+		return fmt.Sprintf("got %s, must be %s", got, article(strings.Join(types, " or ")))
+	case schema.Minimum != nil && below(value, *schema.Minimum):
+		return fmt.Sprintf("got %s, must be at least %v", got, *schema.Minimum)
+	default:
+		return fmt.Sprintf("got %s: %v", got, err)
+	}
+}
+
+func matchesType(typ string, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return typ == "null"
+	case bool:
+		return typ == "boolean"
+	case float64:
+		return typ == "number" || (typ == "integer" && v == float64(int64(v)))
+	case string:
+		return typ == "string"
+	case []any:
+		return typ == "array"
+	case map[string]any:
+		return typ == "object"
+	}
+	return false
+}
+
+func below(value any, minimum float64) bool {
+	n, ok := value.(float64)
+	return ok && n < minimum
+}
+
+func article(typ string) string {
+	switch typ {
+	case "integer", "array", "object":
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+// Enum restricts a property of the inferred schema to the given values.
+func Enum(property string, values ...any) func(*jsonschema.Schema) {
+	return func(s *jsonschema.Schema) {
+		if p := s.Properties[property]; p != nil {
+			p.Enum = values
+		}
+	}
+}
+
+// Minimum sets the minimum value of a numeric property of the inferred schema.
+func Minimum(property string, minimum float64) func(*jsonschema.Schema) {
+	return func(s *jsonschema.Schema) {
+		if p := s.Properties[property]; p != nil {
+			p.Minimum = &minimum
+		}
+	}
+}
+
+// Describe replaces the description of a property of the inferred schema.
+func Describe(property, description string) func(*jsonschema.Schema) {
+	return func(s *jsonschema.Schema) {
+		if p := s.Properties[property]; p != nil {
+			p.Description = description
+		}
+	}
+}
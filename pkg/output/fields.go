@@ -0,0 +1,128 @@
+package output
+
+import (
+	"path"
+	"sync"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+// FieldFilter controls which alert label and annotation keys are shown to the
+// client. Entries may use glob patterns (e.g. "__*", "kubernetes_*").
+// An empty allow list allows every key; the deny list is applied afterwards.
+type FieldFilter struct {
+	LabelAllow      []string
+	LabelDeny       []string
+	AnnotationAllow []string
+	AnnotationDeny  []string
+}
+
+// alwaysShown labels identify an alert and are never filtered out.
+var alwaysShown = []string{"alertname"}
+
+var (
+	mu     sync.RWMutex
+	filter FieldFilter
+)
+
+// SetFieldFilter sets the server-wide label and annotation filter.
+func SetFieldFilter(f FieldFilter) {
+	mu.Lock()
+	defer mu.Unlock()
+	filter = f
+}
+
+func currentFilter() FieldFilter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return filter
+}
+
+// FieldsArgs is the per-call "fields" argument, embedded in tool argument structs.
+type FieldsArgs struct {
+	Fields []string `json:"fields,omitempty" jsonschema:"Extra label/annotation keys to include even if hidden by the server's filters ('*' for all)"`
+}
+
+// LabelVisible reports whether a label key is shown, given the per-call extra fields.
+func LabelVisible(key string, fields []string) bool {
+	f := currentFilter()
+	return matchesAny(key, alwaysShown) || visible(key, f.LabelAllow, f.LabelDeny, fields)
+}
+
+// Labels returns the visible subset of a label set.
+func Labels(labels map[string]string, fields []string) map[string]string {
+	f := currentFilter()
+	return filterMap(labels, func(k string) bool {
+		return matchesAny(k, alwaysShown) || visible(k, f.LabelAllow, f.LabelDeny, fields)
+	})
+}
+
+// Annotations returns the visible subset of an annotation set.
+func Annotations(annotations map[string]string, fields []string) map[string]string {
+	f := currentFilter()
+	return filterMap(annotations, func(k string) bool {
+		return visible(k, f.AnnotationAllow, f.AnnotationDeny, fields)
+	})
+}
+
+// Alert returns a copy of the alert with only visible labels and annotations.
+func Alert(a alertmanager.GettableAlert, fields []string) alertmanager.GettableAlert {
+	a.Labels = Labels(a.Labels, fields)
+	a.Annotations = Annotations(a.Annotations, fields)
+	return a
+}
+
+// Alerts applies Alert to every alert.
+func Alerts(alerts []alertmanager.GettableAlert, fields []string) []alertmanager.GettableAlert {
+	filtered := make([]alertmanager.GettableAlert, len(alerts))
+	for i, a := range alerts {
+		filtered[i] = Alert(a, fields)
+	}
+	return filtered
+}
+
+// AlertGroups applies the filter to group labels and the alerts of each group.
+func AlertGroups(groups []alertmanager.AlertGroup, fields []string) []alertmanager.AlertGroup {
+	filtered := make([]alertmanager.AlertGroup, len(groups))
+	for i, g := range groups {
+		g.Labels = Labels(g.Labels, fields)
+		g.Alerts = Alerts(g.Alerts, fields)
+		filtered[i] = g
+	}
+	return filtered
+}
+
+func visible(key string, allow, deny, fields []string) bool {
+	if matchesAny(key, fields) {
+		return true
+	}
+	if len(allow) > 0 && !matchesAny(key, allow) {
+		return false
+	}
+	return !matchesAny(key, deny)
+}
+
+func matchesAny(key string, patterns []string) bool {
+	for _, p := range patterns {
+		if p == key {
+			return true
+		}
+		if ok, err := path.Match(p, key); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func filterMap(m map[string]string, keep func(string) bool) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if keep(k) {
+			out[k] = v
+		}
+	}
+	return out
+}
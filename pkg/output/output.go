@@ -0,0 +1,14 @@
+// Package output prepares tool results for the client: it applies the
+// server-wide label and annotation filters and serializes results.
+package output
+
+import "encoding/json"
+
+// JSON returns v as indented JSON.
+func JSON(v any) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,328 @@
+package output
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+)
+
+// DefaultLimit is the page size used when a list tool is called without a limit.
+const DefaultLimit = 100
+
+// DefaultMaxBytes is the default server-wide maximum size of a tool result.
+const DefaultMaxBytes = 50000
+
+var maxBytes = DefaultMaxBytes
+
+// SetMaxBytes sets the server-wide maximum size of a tool result in bytes.
+// Zero disables the limit.
+func SetMaxBytes(n int) {
+	mu.Lock()
+	defer mu.Unlock()
+	maxBytes = n
+}
+
+func currentMaxBytes() int {
+	mu.RLock()
+	defer mu.RUnlock()
+	return maxBytes
+}
+
+// Page selects a window of a sorted list.
+type Page struct {
+	Limit  int
+	Offset int
+	SortBy string // field name, prefixed with '-' for descending order
+}
+
+// List is the structured form of one page of a list.
+type List[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total" jsonschema:"number of items across all pages"`
+	Offset int `json:"offset" jsonschema:"index of the first returned item"`
+}
+
+// PageArgs are the paging arguments of list tools, embedded in tool argument structs.
+type PageArgs struct {
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty" jsonschema:"Number of items to skip, for fetching the next page (default: 0)"`
+	SortBy string `json:"sortBy,omitempty"`
+}
+
+// Page returns the requested page, applying the default limit.
+func (a PageArgs) Page() Page {
+	p := Page{Limit: a.Limit, Offset: a.Offset, SortBy: a.SortBy}
+	if p.Limit == 0 {
+		p.Limit = DefaultLimit
+	}
+	return p
+}
+
+// PageSchema refines the inferred schema of a tool embedding PageArgs.
+// sortKeys documents the accepted sortBy values.
+func PageSchema(sortKeys string) func(*jsonschema.Schema) {
+	return func(s *jsonschema.Schema) {
+		mcputil.Describe("limit", fmt.Sprintf("Maximum number of items to return (default: %d)", DefaultLimit))(s)
+		mcputil.Minimum("limit", 1)(s)
+		mcputil.Minimum("offset", 0)(s)
+		mcputil.Describe("sortBy", "Sort field, prefix with '-' for descending: "+sortKeys)(s)
+	}
+}
+
+// Paginate selects the requested page of items and renders it in the given
+// format. If the output or its structured content exceeds the server's
+// maximum output size, fewer items are returned. When not all items are
+// shown, a header explains how to get the rest: a comment line in CSV, and
+// none in JSON, to keep it valid, since its total and offset tell the same.
+func Paginate[T any](items []T, p Page, noun string, format Format, render func([]T) (string, any, error)) (string, error) {
+	total := len(items)
+	start := min(p.Offset, total)
+	end := min(start+p.Limit, total)
+
+	page := items[start:end]
+	limit := currentMaxBytes()
+	sizeLimited := false
+	for {
+		text, data, err := render(page)
+		if err != nil {
+			return "", err
+		}
+		end = start + len(page)
+		if start > 0 || end < total {
+			text = withHeader(format, pageHeader(p, noun, start, end, total, sizeLimited, limit), text)
+		}
+		if limit <= 0 || resultSize(text, data) <= limit || len(page) <= 1 {
+			return text, nil
+		}
+		page = page[:len(page)/2]
+		sizeLimited = true
+	}
+}
+
+// withHeader prepends the page header to the rendered text of a page.
+func withHeader(format Format, header, text string) string {
+	switch format {
+	case FormatJSON:
+		return text
+	case FormatCSV:
+		return "# " + header + "\n" + text
+	default:
+		return header + "\n\n" + text
+	}
+}
+
+// pageHeader describes the returned page of a list.
+func pageHeader(p Page, noun string, start, end, total int, sizeLimited bool, limit int) string {
+	var header strings.Builder
+	if start == end {
+		header.WriteString(fmt.Sprintf("No %s at offset %d (total %d).", noun, start, total))
+	} else {
+		header.WriteString(fmt.Sprintf("Showing %s %d-%d of %d", noun, start+1, end, total))
+		if p.SortBy != "" {
+			header.WriteString(fmt.Sprintf(" (sorted by %s)", p.SortBy))
+		}
+		header.WriteString(".")
+	}
+	if sizeLimited {
+		header.WriteString(fmt.Sprintf(" Page reduced to fit the %d byte output limit.", limit))
+	}
+	if end < total {
+		header.WriteString(fmt.Sprintf(" Use offset=%d for the next page, or narrow the results with filters.", end))
+	}
+	return header.String()
+}
+
+// SortAlerts sorts alerts by startsAt, endsAt, updatedAt, alertname, severity,
+// state, fingerprint or any label name.
+func SortAlerts(alerts []alertmanager.GettableAlert, sortBy string) error {
+	return Sort(alerts, sortBy, func(key string) func(a, b alertmanager.GettableAlert) int {
+		switch key {
+		case "startsAt":
+			return func(a, b alertmanager.GettableAlert) int { return a.StartsAt.Compare(b.StartsAt) }
+		case "endsAt":
+			return func(a, b alertmanager.GettableAlert) int { return a.EndsAt.Compare(b.EndsAt) }
+		case "updatedAt":
+			return func(a, b alertmanager.GettableAlert) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+		case "severity":
+			return func(a, b alertmanager.GettableAlert) int {
+				return cmp.Compare(SeverityRank(a.Labels["severity"]), SeverityRank(b.Labels["severity"]))
+			}
+		case "state":
+			return func(a, b alertmanager.GettableAlert) int { return cmp.Compare(a.Status.State, b.Status.State) }
+		case "fingerprint":
+			return func(a, b alertmanager.GettableAlert) int { return cmp.Compare(a.Fingerprint, b.Fingerprint) }
+		default:
+			return func(a, b alertmanager.GettableAlert) int { return cmp.Compare(a.Labels[key], b.Labels[key]) }
+		}
+	})
+}
+
+// SortSilences sorts silences by startsAt, endsAt, updatedAt, createdBy, state or id.
+func SortSilences(silences []alertmanager.GettableSilence, sortBy string) error {
+	return Sort(silences, sortBy, func(key string) func(a, b alertmanager.GettableSilence) int {
+		switch key {
+		case "startsAt":
+			return func(a, b alertmanager.GettableSilence) int { return a.StartsAt.Compare(b.StartsAt) }
+		case "endsAt":
+			return func(a, b alertmanager.GettableSilence) int { return a.EndsAt.Compare(b.EndsAt) }
+		case "updatedAt":
+			return func(a, b alertmanager.GettableSilence) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+		case "createdBy":
+			return func(a, b alertmanager.GettableSilence) int { return cmp.Compare(a.CreatedBy, b.CreatedBy) }
+		case "state":
+			return func(a, b alertmanager.GettableSilence) int { return cmp.Compare(a.Status.State, b.Status.State) }
+		case "id":
+			return func(a, b alertmanager.GettableSilence) int { return cmp.Compare(a.ID, b.ID) }
+		default:
+			return nil
+		}
+	})
+}
+
+// SortAlertGroups sorts groups by size (number of alerts), receiver or any group label name.
+func SortAlertGroups(groups []alertmanager.AlertGroup, sortBy string) error {
+	return Sort(groups, sortBy, func(key string) func(a, b alertmanager.AlertGroup) int {
+		switch key {
+		case "size":
+			return func(a, b alertmanager.AlertGroup) int { return cmp.Compare(len(a.Alerts), len(b.Alerts)) }
+		case "receiver":
+			return func(a, b alertmanager.AlertGroup) int { return cmp.Compare(a.Receiver.Name, b.Receiver.Name) }
+		default:
+			return func(a, b alertmanager.AlertGroup) int { return cmp.Compare(a.Labels[key], b.Labels[key]) }
+		}
+	})
+}
+
+// SortReceivers sorts receivers by name.
+func SortReceivers(receivers []alertmanager.Receiver, sortBy string) error {
+	return Sort(receivers, sortBy, func(key string) func(a, b alertmanager.Receiver) int {
+		if key != "name" {
+			return nil
+		}
+		return func(a, b alertmanager.Receiver) int { return cmp.Compare(a.Name, b.Name) }
+	})
+}
+
+// SeverityRank orders severities from most to least severe.
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 0
+	case "error", "high":
+		return 1
+	case "warning":
+		return 2
+	case "info":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Sort sorts items by the sortBy field; comparator returns the comparison
+// function for a field name, or nil if the field is not supported.
+func Sort[T any](items []T, sortBy string, comparator func(key string) func(a, b T) int) error {
+	if sortBy == "" {
+		return nil
+	}
+	key, desc := strings.CutPrefix(sortBy, "-")
+	compare := comparator(key)
+	if compare == nil {
+		return fmt.Errorf("unsupported sortBy field %q", key)
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		if desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return nil
+}
+
+// RenderPage renders a page for Paginate like Render, also returning the
+// structured content so that its size counts against the output limit.
+func RenderPage(format Format, data any, report Report) (string, any, error) {
+	text, err := Render(format, data, report)
+	return text, data, err
+}
+
+// resultSize returns the size of a tool result: the larger of its text and
+// the JSON encoding of its structured content.
+func resultSize(text string, data any) int {
+	size := len(text)
+	if data != nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			size = max(size, len(encoded))
+		}
+	}
+	return size
+}
+
+// LimitMiddleware is an MCP receiving middleware that enforces the server's
+// maximum output size. Plain text is truncated; JSON text and structured
+// content, which truncation would make invalid, are replaced with an error.
+func LimitMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		limit := currentMaxBytes()
+		if err != nil || limit <= 0 {
+			return result, err
+		}
+		switch res := result.(type) {
+		case *mcp.CallToolResult:
+			if res.IsError {
+				break
+			}
+			if size := resultSize("", res.StructuredContent); size > limit {
+				return tooLarge(size, limit), nil
+			}
+			for _, c := range res.Content {
+				text, ok := c.(*mcp.TextContent)
+				if !ok || len(text.Text) <= limit {
+					continue
+				}
+				if json.Valid([]byte(text.Text)) {
+					return tooLarge(len(text.Text), limit), nil
+				}
+				text.Text = truncate(text.Text, limit)
+			}
+		case *mcp.ReadResourceResult:
+			for _, c := range res.Contents {
+				if len(c.Text) <= limit {
+					continue
+				}
+				if json.Valid([]byte(c.Text)) {
+					return nil, fmt.Errorf("resource %s is %d bytes, more than the %d byte output limit; use the corresponding tool with filters, limit or offset", c.URI, len(c.Text), limit)
+				}
+				c.Text = truncate(c.Text, limit)
+			}
+		}
+		return result, nil
+	}
+}
+
+// tooLarge returns the error result replacing a tool result over the limit.
+func tooLarge(size, limit int) *mcp.CallToolResult {
+	return mcputil.NewErrorResult(fmt.Sprintf("Output of %d bytes exceeds the %d byte output limit. Narrow the request with filters, limit or offset.", size, limit))
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + fmt.Sprintf("\n\n[Truncated: output exceeded %d bytes of %d. Narrow the request with filters, limit or offset.]", limit, len(s))
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type row struct {
+	Name string `json:"name"`
+}
+
+func rows(n int) []row {
+	items := make([]row, n)
+	for i := range items {
+		items[i] = row{Name: strings.Repeat("x", 20)}
+	}
+	return items
+}
+
+func renderRows(format Format) func([]row) (string, any, error) {
+	return func(items []row) (string, any, error) {
+		t := Table{Columns: []string{"name"}}
+		for _, r := range items {
+			t.Rows = append(t.Rows, []string{r.Name})
+		}
+		return RenderPage(format, items, Report{Tables: []Table{t}})
+	}
+}
+
+func TestPaginateCSV(t *testing.T) {
+	text, err := Paginate(rows(5), Page{Limit: 2}, "rows", FormatCSV, renderRows(FormatCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, body, _ := strings.Cut(text, "\n")
+	if want := "# Showing rows 1-2 of 5. Use offset=2 for the next page"; !strings.HasPrefix(header, want) {
+		t.Errorf("header = %q, want prefix %q", header, want)
+	}
+	r := csv.NewReader(strings.NewReader(text))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("paged CSV is invalid: %v\n%s", err, text)
+	}
+	if len(records) != 3 {
+		t.Errorf("got %d records, want a header and 2 rows:\n%s", len(records), body)
+	}
+}
+
+func setMaxBytes(t *testing.T, n int) {
+	t.Helper()
+	SetMaxBytes(n)
+	t.Cleanup(func() { SetMaxBytes(DefaultMaxBytes) })
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      int
+		page       Page
+		format     Format
+		maxBytes   int
+		wantHeader string
+		wantRows   int
+	}{
+		{"all items", 3, Page{Limit: 10}, FormatTable, 0, "", 3},
+		{"first page", 5, Page{Limit: 2}, FormatTable, 0, "Showing rows 1-2 of 5. Use offset=2 for the next page", 2},
+		{"last page", 5, Page{Limit: 2, Offset: 4}, FormatTable, 0, "Showing rows 5-5 of 5.", 1},
+		{"past the end", 5, Page{Limit: 2, Offset: 9}, FormatTable, 0, "No rows at offset 5 (total 5).", 0},
+		{"sorted", 5, Page{Limit: 2, SortBy: "-name"}, FormatTable, 0, "Showing rows 1-2 of 5 (sorted by -name).", 2},
+		{"size limited", 50, Page{Limit: 50}, FormatTable, 300, "Showing rows 1-6 of 50. Page reduced to fit the 300 byte output limit. Use offset=6", 6},
+		{"json", 5, Page{Limit: 2}, FormatJSON, 0, "", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setMaxBytes(t, tt.maxBytes)
+			var page []row
+			text, err := Paginate(rows(tt.items), tt.page, "rows", tt.format, func(items []row) (string, any, error) {
+				page = items
+				return renderRows(tt.format)(items)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) != tt.wantRows {
+				t.Errorf("rendered %d rows, want %d", len(page), tt.wantRows)
+			}
+			if tt.wantHeader == "" {
+				if strings.HasPrefix(text, "Showing") || strings.HasPrefix(text, "No ") {
+					t.Errorf("unexpected header in %q", text)
+				}
+			} else if !strings.HasPrefix(text, tt.wantHeader) {
+				t.Errorf("text = %q, want header %q", text, tt.wantHeader)
+			}
+			if tt.maxBytes > 0 && len(text) > tt.maxBytes {
+				t.Errorf("text is %d bytes, over the %d byte limit", len(text), tt.maxBytes)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	items := []row{{"b"}, {"c"}, {"a"}}
+	byName := func(key string) func(a, b row) int {
+		if key != "name" {
+			return nil
+		}
+		return func(a, b row) int { return strings.Compare(a.Name, b.Name) }
+	}
+	if err := Sort(items, "-name", byName); err != nil {
+		t.Fatal(err)
+	}
+	if got := items[0].Name + items[1].Name + items[2].Name; got != "cba" {
+		t.Errorf("Sort(-name) = %s, want cba", got)
+	}
+	if err := Sort(items, "size", byName); err == nil || !strings.Contains(err.Error(), `unsupported sortBy field "size"`) {
+		t.Errorf("Sort(size) error = %v", err)
+	}
+}
+
+func TestLimitMiddleware(t *testing.T) {
+	long := strings.Repeat("é", 60)
+	tests := []struct {
+		name      string
+		result    mcp.Result
+		wantError bool
+		wantText  string
+	}{
+		{"small", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, false, "ok"},
+		{"plain text truncated", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: long}}}, false, strings.Repeat("é", 50) + "\n\n[Truncated: output exceeded 100 bytes of 120."},
+		{"json refused", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: `["` + long + `"]`}}}, true, "Output of 124 bytes exceeds the 100 byte output limit."},
+		{"structured refused", &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}, StructuredContent: map[string]string{"x": long}}, true, "exceeds the 100 byte output limit"},
+		{"error kept", &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: long}}}, true, long},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setMaxBytes(t, 100)
+			handler := LimitMiddleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+				return tt.result, nil
+			})
+			result, err := handler(context.Background(), "tools/call", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := result.(*mcp.CallToolResult)
+			text := res.Content[0].(*mcp.TextContent).Text
+			if res.IsError != tt.wantError || !strings.Contains(text, tt.wantText) {
+				t.Errorf("result = %v %q, want %v %q", res.IsError, text, tt.wantError, tt.wantText)
+			}
+			if !utf8.ValidString(text) {
+				t.Errorf("truncated text is not valid UTF-8: %q", text)
+			}
+		})
+	}
+}
+
+func TestLimitMiddlewareResources(t *testing.T) {
+	setMaxBytes(t, 10)
+	result := &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: "alertmanager://config", Text: strings.Repeat("x", 20)}}}
+	handler := LimitMiddleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return result, nil
+	})
+	if _, err := handler(context.Background(), "resources/read", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Contents[0].Text; !strings.HasPrefix(got, strings.Repeat("x", 10)+"\n\n[Truncated") {
+		t.Errorf("Text = %q, want truncated", got)
+	}
+
+	result = &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: "alertmanager://alerts", Text: `["aaaaaaaaaaaaaaa"]`}}}
+	if _, err := handler(context.Background(), "resources/read", nil); err == nil || !strings.Contains(err.Error(), "alertmanager://alerts is 19 bytes") {
+		t.Errorf("error = %v, want the resource refused", err)
+	}
+}
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+)
+
+// Format is an output format selectable by the client.
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+)
+
+// Formats lists the supported output formats.
+var Formats = []Format{FormatTable, FormatMarkdown, FormatJSON, FormatCSV}
+
+// FormatSchema is the schema of the "format" argument.
+func FormatSchema() *jsonschema.Schema {
+	enum := make([]any, len(Formats))
+	for i, f := range Formats {
+		enum[i] = string(f)
+	}
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Output format: 'table' (compact, default), 'markdown', 'json' or 'csv'",
+		Enum:        enum,
+	}
+}
+
+func init() {
+	mcputil.SetTypeSchema[Format](FormatSchema())
+}
+
+// FormatArgs is the "format" argument, embedded in tool argument structs.
+type FormatArgs struct {
+	Format Format `json:"format,omitempty"`
+}
+
+// Table is a titled table of string cells.
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// Report is the tabular view of a tool result: a title, free-form notes and tables.
+type Report struct {
+	Title  string
+	Notes  []string
+	Tables []Table
+}
+
+// Render renders a tool result. The JSON format serializes data; the other
+// formats render the report. An empty format renders a table.
+func Render(format Format, data any, report Report) (string, error) {
+	switch format {
+	case FormatJSON:
+		return JSON(data)
+	case FormatMarkdown:
+		return renderMarkdown(report), nil
+	case FormatCSV:
+		return renderCSV(report)
+	default:
+		return renderTable(report), nil
+	}
+}
+
+func renderTable(r Report) string {
+	var sb strings.Builder
+	if r.Title != "" {
+		sb.WriteString(fmt.Sprintf("=== %s ===\n", r.Title))
+	}
+	for _, n := range r.Notes {
+		sb.WriteString(n + "\n")
+	}
+	for _, t := range r.Tables {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		if t.Title != "" {
+			sb.WriteString(fmt.Sprintf("--- %s ---\n", t.Title))
+		}
+		if len(t.Rows) == 0 {
+			sb.WriteString("None\n")
+			continue
+		}
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.Columns, "\t"))
+		for _, row := range t.Rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = cell(c)
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		tw.Flush()
+	}
+	return sb.String()
+}
+
+func renderMarkdown(r Report) string {
+	var sb strings.Builder
+	if r.Title != "" {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", r.Title))
+	}
+	for _, n := range r.Notes {
+		sb.WriteString(n + "\n\n")
+	}
+	for _, t := range r.Tables {
+		if t.Title != "" {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", t.Title))
+		}
+		if len(t.Rows) == 0 {
+			sb.WriteString("_None_\n\n")
+			continue
+		}
+		sb.WriteString("| " + strings.Join(t.Columns, " | ") + " |\n")
+		sb.WriteString("|" + strings.Repeat(" --- |", len(t.Columns)) + "\n")
+		for _, row := range t.Rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = strings.ReplaceAll(cell(c), "|", `\|`)
+			}
+			sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+func renderCSV(r Report) (string, error) {
+	var sb strings.Builder
+	// Notes come first as comment lines, so notes-only reports are not empty.
+	for _, n := range r.Notes {
+		for _, line := range strings.Split(n, "\n") {
+			sb.WriteString("# " + line + "\n")
+		}
+	}
+	for _, t := range r.Tables {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		// Multiple tables are separated by a comment line naming each table.
+		if len(r.Tables) > 1 && t.Title != "" {
+			sb.WriteString("# " + t.Title + "\n")
+		}
+		w := csv.NewWriter(&sb)
+		if err := w.Write(t.Columns); err != nil {
+			return "", err
+		}
+		if err := w.WriteAll(t.Rows); err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// cell flattens a value so it fits on a single table line.
+func cell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,166 @@
+package output
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+// AlertsTable returns the standard table view of alerts. Labels shown in
+// their own columns are left out of the labels column.
+func AlertsTable(title string, alerts []alertmanager.GettableAlert) Table {
+	t := Table{
+		Title:   title,
+		Columns: []string{"fingerprint", "alertname", "severity", "state", "startsAt", "duration", "labels", "annotations"},
+	}
+	for _, a := range alerts {
+		t.Rows = append(t.Rows, []string{
+			a.Fingerprint,
+			a.Labels["alertname"],
+			a.Labels["severity"],
+			a.Status.State,
+			FormatTime(a.StartsAt),
+			FormatDuration(time.Since(a.StartsAt)),
+			FormatLabels(a.Labels, "alertname", "severity"),
+			FormatLabels(a.Annotations),
+		})
+	}
+	return t
+}
+
+// SilencesTable returns the standard table view of silences.
+func SilencesTable(title string, silences []alertmanager.GettableSilence) Table {
+	t := Table{
+		Title:   title,
+		Columns: []string{"id", "state", "matchers", "startsAt", "endsAt", "createdBy", "comment"},
+	}
+	for _, s := range silences {
+		t.Rows = append(t.Rows, []string{
+			s.ID,
+			s.Status.State,
+			alertmanager.FormatMatchers(s.Matchers),
+			FormatTime(s.StartsAt),
+			FormatTime(s.EndsAt),
+			s.CreatedBy,
+			s.Comment,
+		})
+	}
+	return t
+}
+
+// AlertGroupsTable returns the standard table view of alert groups.
+func AlertGroupsTable(title string, groups []alertmanager.AlertGroup) Table {
+	t := Table{
+		Title:   title,
+		Columns: []string{"labels", "receiver", "alerts", "alertnames"},
+	}
+	for _, g := range groups {
+		names := make(map[string]bool)
+		for _, a := range g.Alerts {
+			names[a.Labels["alertname"]] = true
+		}
+		t.Rows = append(t.Rows, []string{
+			FormatLabels(g.Labels),
+			g.Receiver.Name,
+			strconv.Itoa(len(g.Alerts)),
+			strings.Join(sortedSet(names), ", "),
+		})
+	}
+	return t
+}
+
+// ReceiversTable returns the standard table view of receivers.
+func ReceiversTable(title string, receivers []alertmanager.Receiver) Table {
+	t := Table{Title: title, Columns: []string{"name"}}
+	for _, r := range receivers {
+		t.Rows = append(t.Rows, []string{r.Name})
+	}
+	return t
+}
+
+// CountTable returns a two-column table of names and counts, largest first.
+func CountTable(title, nameColumn, countColumn string, counts map[string]int) Table {
+	t := Table{Title: title, Columns: []string{nameColumn, countColumn}}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		t.Rows = append(t.Rows, []string{name, strconv.Itoa(counts[name])})
+	}
+	return t
+}
+
+// FormatLabels renders a label or annotation set as sorted key=value pairs.
+func FormatLabels(labels map[string]string, skip ...string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		skipped := false
+		for _, s := range skip {
+			if k == s {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// FormatTime renders a timestamp as RFC3339, or empty for the zero time.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// FormatDuration renders a duration rounded to seconds.
+func FormatDuration(d time.Duration) string {
+	return d.Truncate(time.Second).String()
+}
+
+// FormatSeconds renders a number of seconds as a duration.
+func FormatSeconds(n int64) string {
+	return FormatDuration(time.Duration(n) * time.Second)
+}
+
+// PriorityRank orders the priorities of findings (high, medium, low), most
+// urgent first.
+func PriorityRank(priority string) int {
+	switch priority {
+	case "high":
+		return 0
+	case "medium":
+		return 1
+	case "low":
+		return 2
+	default:
+		return 3
+	}
+}
+
+func sortedSet(set map[string]bool) []string {
+	items := make([]string, 0, len(set))
+	for k := range set {
+		items = append(items, k)
+	}
+	sort.Strings(items)
+	return items
+}
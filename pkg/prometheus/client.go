@@ -0,0 +1,120 @@
+// Package prometheus queries a Prometheus-compatible API (Prometheus or
+// Thanos Querier) for the ALERTS series, to reconstruct alert history.
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Client is an HTTP client for the Prometheus v1 query API.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Prometheus API client.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &Client{BaseURL: baseURL, HTTPClient: httpClient}
+}
+
+// Sample is a value of a series at a point in time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a labeled time series of a range query result.
+type Series struct {
+	Labels  map[string]string
+	Samples []Sample
+}
+
+// QueryRange evaluates a PromQL expression over a time range at the given step.
+func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	var result struct {
+		Status    string `json:"status"`
+		ErrorType string `json:"errorType"`
+		Error     string `json:"error"`
+		Data      struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Metric map[string]string `json:"metric"`
+				Values [][2]any          `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("parsing query result: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query failed (%s): %s", result.ErrorType, result.Error)
+	}
+	if result.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected result type %q", result.Data.ResultType)
+	}
+
+	series := make([]Series, 0, len(result.Data.Result))
+	for _, r := range result.Data.Result {
+		s := Series{Labels: r.Metric, Samples: make([]Sample, 0, len(r.Values))}
+		for _, v := range r.Values {
+			sample, err := parseSample(v)
+			if err != nil {
+				return nil, err
+			}
+			s.Samples = append(s.Samples, sample)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// parseSample parses a [<unix time>, "<value>"] pair.
+func parseSample(v [2]any) (Sample, error) {
+	ts, ok := v[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid sample timestamp %v", v[0])
+	}
+	str, ok := v[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid sample value %v", v[1])
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid sample value %q: %w", str, err)
+	}
+	sec := int64(ts)
+	return Sample{Time: time.Unix(sec, int64((ts-float64(sec))*1e9)), Value: value}, nil
+}
@@ -0,0 +1,184 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+)
+
+const (
+	// minStep is the finest resolution of reconstructed intervals; rules are
+	// rarely evaluated more often.
+	minStep = 30 * time.Second
+	// maxPoints keeps range queries below Prometheus' 11,000 points per series.
+	maxPoints = 10000
+)
+
+// History reconstructs alert history from the ALERTS series, which Prometheus
+// writes for every pending or firing alert, and ALERTS_FOR_STATE, whose value
+// is the time the alert became active. It implements history.Source.
+type History struct {
+	client *Client
+}
+
+// NewHistory creates a history source querying the given client.
+func NewHistory(client *Client) *History {
+	return &History{client: client}
+}
+
+// Name implements history.Source.
+func (h *History) Name() string {
+	return "Prometheus ALERTS series at " + h.client.BaseURL
+}
+
+// RecordedSince implements history.Source. It is unknown: it depends on the
+// retention of Prometheus or Thanos.
+func (h *History) RecordedSince() time.Time {
+	return time.Time{}
+}
+
+// Step returns the resolution used to reconstruct intervals over a window.
+func Step(window time.Duration) time.Duration {
+	step := (window / maxPoints).Round(time.Second) + time.Second
+	return max(step, minStep)
+}
+
+// Occurrences implements history.Source. Each firing interval of a label set
+// is an occurrence, accurate to the query step; pending-only intervals are
+// left out because they never reached Alertmanager.
+func (h *History) Occurrences(ctx context.Context, alertName string, since time.Time) ([]history.Occurrence, error) {
+	now := time.Now()
+	step := Step(now.Sub(since))
+	selector := ""
+	if alertName != "" {
+		selector = "{alertname=" + strconv.Quote(alertName) + "}"
+	}
+
+	alerts, err := h.client.QueryRange(ctx, "ALERTS"+selector, since, now, step)
+	if err != nil {
+		return nil, fmt.Errorf("querying ALERTS: %w", err)
+	}
+	activeSince := make(map[string][]Sample)
+	forState, err := h.client.QueryRange(ctx, "ALERTS_FOR_STATE"+selector, since, now, step)
+	if err != nil {
+		// Start times then fall back to the first pending sample.
+		klog.V(2).Infof("Querying ALERTS_FOR_STATE failed: %v", err)
+	}
+	for _, s := range forState {
+		k := seriesKey(alertLabels(s.Labels))
+		activeSince[k] = append(activeSince[k], s.Samples...)
+	}
+
+	// Merge the pending and firing series of each label set into one timeline.
+	timelines := make(map[string][]point)
+	labelSets := make(map[string]map[string]string)
+	for _, s := range alerts {
+		labels := alertLabels(s.Labels)
+		k := seriesKey(labels)
+		labelSets[k] = labels
+		for _, sample := range s.Samples {
+			timelines[k] = append(timelines[k], point{t: sample.Time, state: s.Labels["alertstate"]})
+		}
+	}
+
+	var occurrences []history.Occurrence
+	for k, points := range timelines {
+		sort.SliceStable(points, func(i, j int) bool { return points[i].t.Before(points[j].t) })
+		for start := 0; start < len(points); {
+			end := start
+			for end+1 < len(points) && points[end+1].t.Sub(points[end].t) <= step+step/2 {
+				end++
+			}
+			if o, ok := occurrence(labelSets[k], points[start:end+1], activeSince[k], now, step); ok {
+				occurrences = append(occurrences, o)
+			}
+			start = end + 1
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool {
+		if !occurrences[i].StartsAt.Equal(occurrences[j].StartsAt) {
+			return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+		}
+		return occurrences[i].Fingerprint < occurrences[j].Fingerprint
+	})
+	return occurrences, nil
+}
+
+// point is an ALERTS sample with the alert state of its series.
+type point struct {
+	t     time.Time
+	state string
+}
+
+// occurrence builds the occurrence of one contiguous interval of samples,
+// or reports false if the alert never fired in it.
+func occurrence(labels map[string]string, points []point, activeSince []Sample, now time.Time, step time.Duration) (history.Occurrence, bool) {
+	first, last := points[0].t, points[len(points)-1].t
+	firing := -1
+	for i, p := range points {
+		if p.state == "firing" {
+			firing = i
+			break
+		}
+	}
+	if firing < 0 {
+		return history.Occurrence{}, false
+	}
+
+	startsAt := first
+	for _, s := range activeSince {
+		if !s.Time.Before(first) && !s.Time.After(last) && s.Value > 0 {
+			startsAt = time.Unix(int64(s.Value), 0)
+			break
+		}
+	}
+	o := history.Occurrence{
+		Fingerprint: alertmanager.Fingerprint(labels),
+		Labels:      labels,
+		StartsAt:    startsAt,
+	}
+	if firing > 0 {
+		o.Transitions = append(o.Transitions, history.Transition{Time: startsAt, State: "pending"})
+	}
+	o.Transitions = append(o.Transitions, history.Transition{Time: points[firing].t, State: "firing"})
+	// An interval reaching the end of the query is still firing. Otherwise it
+	// resolved between its last sample and the next step.
+	if now.Sub(last) > step+step/2 {
+		end := last.Add(step / 2)
+		o.EndsAt = &end
+		o.Transitions = append(o.Transitions, history.Transition{Time: end, State: history.Resolved})
+	}
+	return o, true
+}
+
+// alertLabels returns the alert's labels without the series name and state.
+func alertLabels(series map[string]string) map[string]string {
+	labels := make(map[string]string, len(series))
+	for k, v := range series {
+		if k != "__name__" && k != "alertstate" {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, k := range names {
+		sb.WriteString(k + "\xff" + labels[k] + "\xff")
+	}
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+const defaultShift = "12h"
+
+func registerShiftHandoverReport(s *mcp.Server, client *alertmanager.Client) {
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "shift-handover-report",
+		Title:       "Shift Handover Report",
+		Description: "Write an on-call handover: new and ongoing alerts, suppressed alerts and silences expiring during the next shift.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "shift", Description: "Shift length, e.g. '8h', '12h' or '7h30m' (default: 12h)"},
+		},
+	}, func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		period := strings.TrimSpace(request.Params.Arguments["shift"])
+		if period == "" {
+			period = defaultShift
+		}
+		shift, err := timeutil.ParseDuration(period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument shift: %v", err)
+		}
+		if shift <= 0 {
+			return nil, fmt.Errorf("invalid argument shift: %q is not a positive duration such as '8h'", period)
+		}
+		now := time.Now()
+		shiftStart := now.Add(-shift)
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		output.SortAlerts(alerts, "severity")
+		var firing, started, ongoing, suppressed []alertmanager.GettableAlert
+		for _, a := range alerts {
+			if a.Status.State != "active" {
+				suppressed = append(suppressed, a)
+				continue
+			}
+			firing = append(firing, a)
+			if a.StartsAt.After(shiftStart) {
+				started = append(started, a)
+			} else {
+				ongoing = append(ongoing, a)
+			}
+		}
+
+		silences, err := liveSilences(client)
+		if err != nil {
+			return nil, err
+		}
+		var expiring, created []alertmanager.GettableSilence
+		for _, sil := range silences {
+			if sil.EndsAt.Before(now.Add(shift)) {
+				expiring = append(expiring, sil)
+			}
+			if sil.StartsAt.After(shiftStart) || sil.UpdatedAt.After(shiftStart) {
+				created = append(created, sil)
+			}
+		}
+
+		instructions := fmt.Sprintf(`Write my on-call shift handover report for the last %s using the current Alertmanager data below. Structure it as:
+
+1. Overall state: one or two sentences on how healthy things are.
+2. New this shift: alerts that started during the shift, grouped by likely cause, with what was done or still needs doing.
+3. Ongoing: alerts that were already firing before the shift and are still open.
+4. Suppressed: silenced or inhibited alerts, and whether each silence still looks justified.
+5. Watch next shift: silences expiring during the next %s, and anything likely to fire again.
+6. Follow-ups: concrete actions for the next on-call.
+
+Keep it concise and skimmable; the reader is taking over the pager.`, period, period)
+
+		report := output.Report{
+			Title: fmt.Sprintf("Alerting State at %s", output.FormatTime(now)),
+			Notes: []string{fmt.Sprintf("Firing: %d (new this shift: %d, ongoing: %d), suppressed: %d, live silences: %d",
+				len(firing), len(started), len(ongoing), len(suppressed), len(silences))},
+			Tables: []output.Table{
+				output.CountTable("Firing by Severity", "severity", "alerts", countBy(firing, "severity")),
+				alertsTable("Started This Shift", started),
+				alertsTable("Ongoing From Before the Shift", ongoing),
+				alertsTable("Silenced or Inhibited", suppressed),
+				silencesTable("Silences Expiring Next Shift", expiring),
+				silencesTable("Silences Created or Updated This Shift", created),
+			},
+		}
+		return newPromptResult("Shift handover report for the last "+period, instructions, report), nil
+	})
+}
@@ -0,0 +1,74 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+func registerInvestigateAlert(s *mcp.Server, client *alertmanager.Client) {
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "investigate-alert",
+		Title:       "Investigate Alert",
+		Description: "Investigate one alert: scope, duration, silences and inhibitions, likely cause and remediation.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "alertName", Description: "Alert name to investigate, e.g. KubePodCrashLooping", Required: true},
+		},
+	}, func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		alertName, err := requiredArgument(request, "alertName")
+		if err != nil {
+			return nil, err
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		var instances []alertmanager.GettableAlert
+		for _, a := range alerts {
+			if a.Labels["alertname"] == alertName {
+				instances = append(instances, a)
+			}
+		}
+		output.SortAlerts(instances, "startsAt")
+
+		silences, err := liveSilences(client)
+		if err != nil {
+			return nil, err
+		}
+		var matching []alertmanager.GettableSilence
+		for _, sil := range silences {
+			for _, a := range instances {
+				if alertmanager.MatchAll(sil.Matchers, a.Labels) {
+					matching = append(matching, sil)
+					break
+				}
+			}
+		}
+
+		instructions := fmt.Sprintf(`Investigate the %s alert using the current Alertmanager data below:
+
+1. Describe the scope: how many instances, which namespaces, pods, nodes or services, and since when.
+2. Say which instances are silenced or inhibited, and by which silence or rule.
+3. Explain the likely cause from the labels and annotations, and point me to the runbook_url annotation when there is one.
+4. Use correlateAlerts to find related alerts that may be the real root cause, and getAlertHistory to check whether this alert keeps coming back.
+5. Recommend remediation steps, most likely fix first.`, alertName)
+
+		report := output.Report{
+			Title:  "Alert " + alertName,
+			Notes:  []string{fmt.Sprintf("Instances (any state): %d", len(instances))},
+			Tables: []output.Table{alertsTable("Instances", instances)},
+		}
+		if len(instances) == 0 {
+			report.Notes = append(report.Notes, "No instances of this alert are currently known to Alertmanager. Check the alert name, or use getAlertHistory to find past occurrences.")
+		}
+		if len(matching) > 0 {
+			report.Tables = append(report.Tables, silencesTable("Silences Matching Instances", matching))
+		}
+		return newPromptResult("Investigation of the "+alertName+" alert", instructions, report), nil
+	})
+}
@@ -0,0 +1,99 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+func registerPlanMaintenanceSilence(s *mcp.Server, client *alertmanager.Client) {
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "plan-maintenance-silence",
+		Title:       "Plan Maintenance Silence",
+		Description: "Plan a silence for a namespace maintenance window: what it would mute, overlapping silences and risks, then start it with startMaintenance.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "namespace", Description: "Namespace under maintenance", Required: true},
+			{Name: "duration", Description: "Length of the maintenance window, e.g. '30m', '1h30m', '1d' or 'until 18:00'", Required: true},
+		},
+	}, func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		namespace, err := requiredArgument(request, "namespace")
+		if err != nil {
+			return nil, err
+		}
+		duration, err := requiredArgument(request, "duration")
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		length, err := timeutil.ParseLength(duration, now, timeutil.Location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument duration: %v", err)
+		}
+		until := output.FormatTime(now.Add(length))
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		selector := map[string]string{"namespace": namespace}
+		var affected []alertmanager.GettableAlert
+		critical := 0
+		for _, a := range alerts {
+			if a.Labels["namespace"] == namespace {
+				affected = append(affected, a)
+				if a.Labels["severity"] == "critical" {
+					critical++
+				}
+			}
+		}
+		output.SortAlerts(affected, "severity")
+
+		silences, err := liveSilences(client)
+		if err != nil {
+			return nil, err
+		}
+		var overlapping []alertmanager.GettableSilence
+		for _, sil := range silences {
+			if silencesNamespace(sil, selector) {
+				overlapping = append(overlapping, sil)
+			}
+		}
+
+		instructions := fmt.Sprintf(`I am planning a maintenance window for namespace %s from now until %s and want to silence its alerts. Using the current Alertmanager data below, write a silence plan:
+
+1. Propose the silence matchers, starting from namespace=%q. Narrow them if muting the whole namespace would hide alerts that should still page during maintenance.
+2. List the currently known alerts the silence would mute, and call out critical ones.
+3. Point out existing silences that already cover this namespace, so we do not create duplicates.
+4. Once I confirm the plan, call the startMaintenance tool with namespace=%q and duration=%q and a comment naming the maintenance. If you narrowed the matchers in step 1, pass them as matchers instead of namespace, including namespace=%q. Report the maintenance ID it returns.
+5. List what to check when the window ends: call endMaintenance with the maintenance ID if maintenance finishes sooner, and review alerts that are still firing.
+
+Do not call startMaintenance until I confirm the plan.`, namespace, until, namespace, namespace, duration, namespace)
+
+		report := output.Report{
+			Title: fmt.Sprintf("Namespace %s", namespace),
+			Notes: []string{fmt.Sprintf("Known alerts in the namespace: %d, critical: %d", len(affected), critical)},
+			Tables: []output.Table{
+				alertsTable("Alerts the Silence Would Mute", affected),
+				silencesTable("Existing Silences Covering the Namespace", overlapping),
+			},
+		}
+		return newPromptResult(fmt.Sprintf("Silence plan for a maintenance of namespace %s until %s", namespace, until), instructions, report), nil
+	})
+}
+
+// silencesNamespace reports whether a silence has a namespace matcher that
+// matches the selector's namespace.
+func silencesNamespace(sil alertmanager.GettableSilence, selector map[string]string) bool {
+	for _, m := range sil.Matchers {
+		if m.Name == "namespace" && m.Matches(selector) {
+			return true
+		}
+	}
+	return false
+}
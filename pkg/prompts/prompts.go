@@ -0,0 +1,96 @@
+// Package prompts registers MCP prompts for incident workflows. Each prompt
+// embeds the current Alertmanager data, so the assistant starts from the live
+// state instead of having to fetch it first.
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// Register registers all prompts.
+func Register(s *mcp.Server, client *alertmanager.Client) {
+	registerTriageCriticalAlerts(s, client)
+	registerInvestigateAlert(s, client)
+	registerPlanMaintenanceSilence(s, client)
+	registerShiftHandoverReport(s, client)
+}
+
+// maxRows bounds the rows of each embedded table; prompts are not paginated.
+const maxRows = 50
+
+// requiredArgument returns a prompt argument, or an error if it is empty.
+func requiredArgument(request *mcp.GetPromptRequest, name string) (string, error) {
+	value := strings.TrimSpace(request.Params.Arguments[name])
+	if value == "" {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return value, nil
+}
+
+// newPromptResult returns a prompt made of a single user message: the
+// instructions followed by the current data.
+func newPromptResult(description, instructions string, data output.Report) *mcp.GetPromptResult {
+	text, _ := output.Render(output.FormatMarkdown, nil, data)
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []*mcp.PromptMessage{{
+			Role:    "user",
+			Content: &mcp.TextContent{Text: instructions + "\n\n" + text},
+		}},
+	}
+}
+
+// alertsTable returns the standard alerts table with visible fields only,
+// limited to maxRows.
+func alertsTable(title string, alerts []alertmanager.GettableAlert) output.Table {
+	t := output.AlertsTable(title, output.Alerts(alerts, nil))
+	return limitRows(t, len(alerts))
+}
+
+// silencesTable returns the standard silences table limited to maxRows.
+func silencesTable(title string, silences []alertmanager.GettableSilence) output.Table {
+	return limitRows(output.SilencesTable(title, silences), len(silences))
+}
+
+func limitRows(t output.Table, total int) output.Table {
+	if total > maxRows {
+		t.Rows = t.Rows[:maxRows]
+		t.Title = fmt.Sprintf("%s (first %d of %d)", t.Title, maxRows, total)
+	}
+	return t
+}
+
+// liveSilences returns the active and pending silences.
+func liveSilences(client *alertmanager.Client) ([]alertmanager.GettableSilence, error) {
+	silences, err := client.GetSilences("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get silences: %w", err)
+	}
+	live := silences[:0]
+	for _, s := range silences {
+		if s.Status.State != "expired" {
+			live = append(live, s)
+		}
+	}
+	output.SortSilences(live, "endsAt")
+	return live, nil
+}
+
+// countBy counts alerts per value of a label; alerts without it count as "unknown".
+func countBy(alerts []alertmanager.GettableAlert, label string) map[string]int {
+	counts := make(map[string]int)
+	for _, a := range alerts {
+		value := a.Labels[label]
+		if value == "" {
+			value = "unknown"
+		}
+		counts[value]++
+	}
+	return counts
+}
@@ -0,0 +1,67 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+func registerTriageCriticalAlerts(s *mcp.Server, client *alertmanager.Client) {
+	s.AddPrompt(&mcp.Prompt{
+		Name:        "triage-critical-alerts",
+		Title:       "Triage Critical Alerts",
+		Description: "Prioritize the firing critical alerts, group them by likely root cause and recommend next actions.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "namespace", Description: "Only triage alerts in this namespace (default: all namespaces)"},
+		},
+	}, func(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		namespace := strings.TrimSpace(request.Params.Arguments["namespace"])
+
+		alerts, err := client.GetAlertsRaw("true", "false", "false")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		var firing, critical []alertmanager.GettableAlert
+		for _, a := range alerts {
+			if namespace != "" && a.Labels["namespace"] != namespace {
+				continue
+			}
+			firing = append(firing, a)
+			if strings.EqualFold(a.Labels["severity"], "critical") {
+				critical = append(critical, a)
+			}
+		}
+		output.SortAlerts(critical, "startsAt")
+
+		scope := "the cluster"
+		if namespace != "" {
+			scope = "namespace " + namespace
+		}
+		instructions := fmt.Sprintf(`I am on call and need to triage the critical alerts firing in %s. Using the current Alertmanager data below:
+
+1. Prioritize the critical alerts by likely user impact and how long they have been firing.
+2. Group alerts that probably share a root cause (same namespace, node, service or job). Use the correlateAlerts tool to confirm.
+3. For the top items, use investigateAlert and summarize the likely cause. Point me to the runbook_url annotation when there is one.
+4. Recommend the next actions: what to check first, who to involve, and whether a silence is justified. Do not create silences without asking me.
+
+Answer with a short prioritized list followed by the actions.`, scope)
+
+		report := output.Report{
+			Title: "Current Alerts in " + scope,
+			Notes: []string{fmt.Sprintf("Firing (not silenced or inhibited): %d, critical: %d", len(firing), len(critical))},
+			Tables: []output.Table{
+				output.CountTable("Firing by Severity", "severity", "alerts", countBy(firing, "severity")),
+				alertsTable("Critical Alerts", critical),
+			},
+		}
+		if len(critical) == 0 {
+			report.Notes = append(report.Notes, "No critical alerts are firing; triage the highest severity alerts instead.")
+		}
+		return newPromptResult("Triage of the critical alerts firing in "+scope, instructions, report), nil
+	})
+}
@@ -0,0 +1,179 @@
+// Package resources exposes Alertmanager alerts, silences, status and
+// configuration as MCP resources, so clients can attach them to a
+// conversation without a tool call.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// Resource URIs. Templates use RFC 6570 variables.
+const (
+	AlertsURI          = "alertmanager://alerts"
+	AlertURITemplate   = "alertmanager://alerts/{fingerprint}"
+	SilencesURI        = "alertmanager://silences"
+	SilenceURITemplate = "alertmanager://silences/{id}"
+	StatusURI          = "alertmanager://status"
+	ConfigURI          = "alertmanager://config"
+)
+
+const (
+	jsonMIME = "application/json"
+	yamlMIME = "application/yaml"
+)
+
+// AlertURI returns the URI of the alert with the given fingerprint.
+func AlertURI(fingerprint string) string {
+	return AlertsURI + "/" + fingerprint
+}
+
+// SilenceURI returns the URI of the silence with the given ID.
+func SilenceURI(id string) string {
+	return SilencesURI + "/" + id
+}
+
+// Register registers all Alertmanager resources and resource templates.
+func Register(s *mcp.Server, client *alertmanager.Client) {
+	s.AddResource(&mcp.Resource{
+		URI:         AlertsURI,
+		Name:        "alerts",
+		Title:       "Active Alerts",
+		Description: "Active (not silenced or inhibited) alerts, with labels and annotations filtered like tool output.",
+		MIMEType:    jsonMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		alerts, err := client.GetAlertsRaw("true", "false", "false")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		output.SortAlerts(alerts, "startsAt")
+		return jsonResult(request.Params.URI, output.Alerts(alerts, nil))
+	})
+
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: AlertURITemplate,
+		Name:        "alert",
+		Title:       "Alert",
+		Description: "A single alert in any state (active, silenced or inhibited), by fingerprint.",
+		MIMEType:    jsonMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := request.Params.URI
+		fingerprint := strings.TrimPrefix(uri, AlertsURI+"/")
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %w", err)
+		}
+		for _, a := range alerts {
+			if a.Fingerprint == fingerprint {
+				return jsonResult(uri, output.Alert(a, nil))
+			}
+		}
+		return nil, mcp.ResourceNotFoundError(uri)
+	})
+
+	s.AddResource(&mcp.Resource{
+		URI:         SilencesURI,
+		Name:        "silences",
+		Title:       "Silences",
+		Description: "Active and pending silences.",
+		MIMEType:    jsonMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		silences, err := client.GetSilences("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get silences: %w", err)
+		}
+		current := make([]alertmanager.GettableSilence, 0, len(silences))
+		for _, sil := range silences {
+			if sil.Status.State != "expired" {
+				current = append(current, sil)
+			}
+		}
+		output.SortSilences(current, "endsAt")
+		return jsonResult(request.Params.URI, current)
+	})
+
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: SilenceURITemplate,
+		Name:        "silence",
+		Title:       "Silence",
+		Description: "A single silence in any state (active, pending or expired), by ID.",
+		MIMEType:    jsonMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := request.Params.URI
+		id := strings.TrimPrefix(uri, SilencesURI+"/")
+		silences, err := client.GetSilences("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get silences: %w", err)
+		}
+		for _, sil := range silences {
+			if sil.ID == id {
+				return jsonResult(uri, sil)
+			}
+		}
+		return nil, mcp.ResourceNotFoundError(uri)
+	})
+
+	s.AddResource(&mcp.Resource{
+		URI:         StatusURI,
+		Name:        "status",
+		Title:       "Alertmanager Status",
+		Description: "Alertmanager version, uptime and cluster status. The configuration is available as " + ConfigURI + ".",
+		MIMEType:    jsonMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		status, err := client.GetStatusRaw()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status: %w", err)
+		}
+		return jsonResult(request.Params.URI, statusResource{
+			Cluster:     status.Cluster,
+			Uptime:      status.Uptime,
+			VersionInfo: status.VersionInfo,
+		})
+	})
+
+	s.AddResource(&mcp.Resource{
+		URI:         ConfigURI,
+		Name:        "config",
+		Title:       "Alertmanager Configuration",
+		Description: "The running Alertmanager configuration as YAML, with secrets redacted.",
+		MIMEType:    yamlMIME,
+	}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		status, err := client.GetStatusRaw()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status: %w", err)
+		}
+		// Secrets are masked by the server's redaction middleware.
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{
+			URI:      request.Params.URI,
+			MIMEType: yamlMIME,
+			Text:     status.Config.Original,
+		}}}, nil
+	})
+}
+
+// statusResource is the Alertmanager status without the configuration.
+type statusResource struct {
+	Cluster     alertmanager.ClusterStatus `json:"cluster"`
+	Uptime      time.Time                  `json:"uptime"`
+	VersionInfo alertmanager.VersionInfo   `json:"versionInfo"`
+}
+
+func jsonResult(uri string, data any) (*mcp.ReadResourceResult, error) {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", uri, err)
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{
+		URI:      uri,
+		MIMEType: jsonMIME,
+		Text:     string(b),
+	}}}, nil
+}
@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/watcher"
+)
+
+// Subscribe accepts subscriptions to alert and silence resources, which the
+// watcher keeps up to date. Use it as the server's SubscribeHandler.
+func Subscribe(ctx context.Context, request *mcp.SubscribeRequest) error {
+	uri := request.Params.URI
+	switch {
+	case uri == AlertsURI, uri == SilencesURI:
+		return nil
+	case strings.HasPrefix(uri, AlertsURI+"/") && len(uri) > len(AlertsURI)+1:
+		return nil
+	case strings.HasPrefix(uri, SilencesURI+"/") && len(uri) > len(SilencesURI)+1:
+		return nil
+	}
+	return fmt.Errorf("subscriptions are only supported for %s, %s, %s and %s", AlertsURI, AlertURITemplate, SilencesURI, SilenceURITemplate)
+}
+
+// Unsubscribe is the server's UnsubscribeHandler; the server itself tracks
+// subscriptions.
+func Unsubscribe(ctx context.Context, request *mcp.UnsubscribeRequest) error {
+	return nil
+}
+
+// Notify returns a watcher handler that sends resources/updated notifications
+// to the clients subscribed to the resources affected by the events.
+func Notify(s *mcp.Server) watcher.Handler {
+	return func(ctx context.Context, snapshot *watcher.Snapshot, events []watcher.Event) {
+		var uris []string
+		seen := make(map[string]bool)
+		add := func(uri string) {
+			if !seen[uri] {
+				seen[uri] = true
+				uris = append(uris, uri)
+			}
+		}
+		for _, e := range events {
+			switch {
+			case e.Alert != nil:
+				add(AlertsURI)
+				add(AlertURI(e.Alert.Fingerprint))
+			case e.Silence != nil:
+				add(SilencesURI)
+				add(SilenceURI(e.Silence.ID))
+			}
+		}
+		for _, uri := range uris {
+			s.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+		}
+	}
+}
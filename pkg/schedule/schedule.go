@@ -0,0 +1,205 @@
+// Package schedule creates the silences of recurring maintenance windows,
+// such as weekly database patching, ahead of each window.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+const (
+	// DefaultLead is how long before a window its silence is created.
+	DefaultLead = 24 * time.Hour
+	// syncInterval is how often the scheduler checks for upcoming windows.
+	syncInterval = time.Minute
+)
+
+var validName = regexp.MustCompile(`^[\w-]+$`)
+
+// Config is the content of a schedule file.
+type Config struct {
+	// Lead is how long before each window its silence is created, e.g. '12h'
+	// (default: 24h).
+	Lead    string   `json:"lead,omitempty"`
+	Windows []Window `json:"windows"`
+}
+
+// Window is a recurring maintenance window.
+type Window struct {
+	// Name identifies the window in the comment of its silences.
+	Name string `json:"name"`
+	// Matchers select the alerts to silence, e.g. '{namespace="databases"}'.
+	Matchers string `json:"matchers"`
+	// Start is a daily or weekly time, e.g. 'Saturday 02:00 Europe/Madrid'.
+	Start string `json:"start"`
+	// Duration is the length of the window, e.g. '3h'.
+	Duration  string `json:"duration"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// window is a validated Window.
+type window struct {
+	Window
+	matchers []alertmanager.Matcher
+	start    timeutil.Recurrence
+	duration time.Duration
+}
+
+// Scheduler creates a pending silence for each window once the window is
+// less than the lead time away. It finds the silences it created by the tag
+// in their comment, e.g. "[schedule db-patching 2026-10-24T00:00:00Z]", so it
+// keeps no state and never re-creates a silence expired early.
+type Scheduler struct {
+	client  *alertmanager.Client
+	lead    time.Duration
+	windows []window
+}
+
+// Load reads and validates a schedule file.
+func Load(path string, client *alertmanager.Client) (*Scheduler, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schedule file: %w", err)
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing schedule file %s: %w", path, err)
+	}
+	s, err := New(cfg, client)
+	if err != nil {
+		return nil, fmt.Errorf("schedule file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// New validates a schedule configuration and returns its scheduler.
+func New(cfg Config, client *alertmanager.Client) (*Scheduler, error) {
+	s := &Scheduler{client: client, lead: DefaultLead}
+	if cfg.Lead != "" {
+		lead, err := timeutil.ParseDuration(cfg.Lead)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lead: %w", err)
+		}
+		s.lead = lead
+	}
+	names := make(map[string]bool)
+	for _, w := range cfg.Windows {
+		if !validName.MatchString(w.Name) {
+			return nil, fmt.Errorf("window %q: name must be letters, digits, '_' and '-'", w.Name)
+		}
+		if names[w.Name] {
+			return nil, fmt.Errorf("window %q: duplicate name", w.Name)
+		}
+		names[w.Name] = true
+
+		parsed := window{Window: w}
+		var err error
+		if parsed.matchers, err = alertmanager.ParseMatchers(w.Matchers); err != nil {
+			return nil, fmt.Errorf("window %q: invalid matchers: %w", w.Name, err)
+		}
+		if len(parsed.matchers) == 0 {
+			return nil, fmt.Errorf("window %q: matchers must not be empty", w.Name)
+		}
+		if parsed.start, err = timeutil.ParseRecurrence(w.Start, timeutil.Location()); err != nil {
+			return nil, fmt.Errorf("window %q: invalid start: %w", w.Name, err)
+		}
+		if parsed.duration, err = timeutil.ParseDuration(w.Duration); err != nil {
+			return nil, fmt.Errorf("window %q: invalid duration: %w", w.Name, err)
+		}
+		if parsed.duration <= 0 || parsed.duration >= parsed.start.Period() {
+			return nil, fmt.Errorf("window %q: duration must be positive and shorter than the time between two windows", w.Name)
+		}
+		s.windows = append(s.windows, parsed)
+	}
+	return s, nil
+}
+
+// Run syncs every minute until ctx is done. Failed syncs are logged and
+// retried at the next interval.
+func (s *Scheduler) Run(ctx context.Context) {
+	klog.V(1).Infof("Scheduling %d maintenance window(s) %s ahead", len(s.windows), s.lead)
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(time.Now()); err != nil {
+			klog.Warningf("Scheduling maintenance windows failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync creates the silence of each window that is in progress or starts
+// within the lead time, unless it was already created.
+func (s *Scheduler) Sync(now time.Time) error {
+	silences, err := s.client.GetSilences("")
+	if err != nil {
+		return fmt.Errorf("failed to get silences: %w", err)
+	}
+	var errs []error
+	for _, w := range s.windows {
+		// The window in progress, if any, otherwise the next one.
+		start := w.start.Next(now.Add(-w.duration))
+		if start.Sub(now) > s.lead {
+			continue
+		}
+		tag := fmt.Sprintf("[schedule %s %s]", w.Name, start.UTC().Format(time.RFC3339))
+		if created(silences, tag) {
+			continue
+		}
+		silence := w.silence(start, now)
+		silence.Comment = tag + " " + silence.Comment
+		id, err := s.client.CreateSilence(silence)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("window %s: failed to create silence: %w", w.Name, err))
+			continue
+		}
+		klog.V(1).Infof("Created silence %s for maintenance window %s from %s to %s", id, w.Name, silence.StartsAt.Format(time.RFC3339), silence.EndsAt.Format(time.RFC3339))
+	}
+	return errors.Join(errs...)
+}
+
+// silence returns the silence of the window starting at start; a window in
+// progress is silenced from now.
+func (w window) silence(start, now time.Time) alertmanager.PostableSilence {
+	comment := w.Comment
+	if comment == "" {
+		comment = "Maintenance window " + w.Name
+	}
+	createdBy := w.CreatedBy
+	if createdBy == "" {
+		createdBy = "mcp-alertmanager"
+	}
+	return alertmanager.PostableSilence{
+		Comment:   comment,
+		CreatedBy: createdBy,
+		StartsAt:  timeutil.Later(start, now),
+		EndsAt:    start.Add(w.duration),
+		Matchers:  w.matchers,
+	}
+}
+
+// created reports whether a silence with the tag exists in any state.
+func created(silences []alertmanager.GettableSilence, tag string) bool {
+	for _, s := range silences {
+		if strings.Contains(s.Comment, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+// fakeAlertmanager serves the silences API, keeping the silences created.
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	silences []alertmanager.PostableSilence
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Method == http.MethodPost {
+		var s alertmanager.PostableSilence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.silences = append(f.silences, s)
+		w.Write([]byte(`{"silenceID":"new"}`))
+		return
+	}
+	json.NewEncoder(w).Encode(f.silences)
+}
+
+func newScheduler(t *testing.T, cfg Config, f *fakeAlertmanager) *Scheduler {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	s, err := New(cfg, alertmanager.NewClient(srv.URL, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    Window
+		lead      string
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		wantTag   string
+		wantNone  bool
+	}{
+		{
+			name:      "autumn DST change",
+			window:    Window{Name: "daily", Start: "04:00 Europe/Madrid", Duration: "1h"},
+			now:       time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 10, 25, 4, 0, 0, 0, time.UTC),
+			wantTag:   "[schedule daily 2026-10-25T03:00:00Z]",
+		},
+		{
+			name:      "spring DST change",
+			window:    Window{Name: "daily", Start: "04:00 Europe/Madrid", Duration: "1h"},
+			now:       time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 3, 29, 2, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 3, 29, 3, 0, 0, 0, time.UTC),
+			wantTag:   "[schedule daily 2026-03-29T02:00:00Z]",
+		},
+		{
+			name:      "in progress",
+			window:    Window{Name: "nightly", Start: "03:00 UTC", Duration: "2h"},
+			now:       time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 10, 18, 5, 0, 0, 0, time.UTC),
+			wantTag:   "[schedule nightly 2026-10-18T03:00:00Z]",
+		},
+		{
+			name:     "beyond the lead time",
+			window:   Window{Name: "weekly", Start: "Saturday 02:00 UTC", Duration: "3h"},
+			lead:     "12h",
+			now:      time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			wantNone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.window.Matchers = `{job="batch"}`
+			f := &fakeAlertmanager{}
+			s := newScheduler(t, Config{Lead: tt.lead, Windows: []Window{tt.window}}, f)
+			if err := s.Sync(tt.now); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNone {
+				if len(f.silences) != 0 {
+					t.Fatalf("Sync() created %d silences, want none", len(f.silences))
+				}
+				return
+			}
+			if len(f.silences) != 1 {
+				t.Fatalf("Sync() created %d silences, want 1", len(f.silences))
+			}
+			got := f.silences[0]
+			if !got.StartsAt.Equal(tt.wantStart) || !got.EndsAt.Equal(tt.wantEnd) {
+				t.Errorf("silence from %v to %v, want %v to %v", got.StartsAt, got.EndsAt, tt.wantStart, tt.wantEnd)
+			}
+			if !strings.HasPrefix(got.Comment, tt.wantTag+" ") {
+				t.Errorf("comment = %q, want tag %q", got.Comment, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestSyncIsIdempotent(t *testing.T) {
+	f := &fakeAlertmanager{}
+	s := newScheduler(t, Config{Windows: []Window{
+		{Name: "nightly", Matchers: `{job="batch"}`, Start: "03:00 UTC", Duration: "2h"},
+	}}, f)
+	now := time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now, now, now.Add(time.Minute), now.Add(3 * time.Hour)} {
+		if err := s.Sync(at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(f.silences) != 1 {
+		t.Fatalf("Sync() created %d silences for one window, want 1", len(f.silences))
+	}
+	// The next day's window gets its own silence.
+	if err := s.Sync(now.Add(24 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.silences) != 2 {
+		t.Fatalf("Sync() created %d silences for two windows, want 2", len(f.silences))
+	}
+}
+
+func TestNewRejectsInvalidWindows(t *testing.T) {
+	tests := []struct {
+		name   string
+		window Window
+		want   string
+	}{
+		{"name", Window{Name: "a b", Matchers: `{a="b"}`, Start: "02:00", Duration: "1h"}, "name must be"},
+		{"matchers", Window{Name: "a", Start: "02:00", Duration: "1h"}, "matchers must not be empty"},
+		{"start", Window{Name: "a", Matchers: `{a="b"}`, Start: "tomorrow 02:00", Duration: "1h"}, "invalid start"},
+		{"duration", Window{Name: "a", Matchers: `{a="b"}`, Start: "02:00", Duration: "2d"}, "shorter than the time between two windows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(Config{Windows: []Window{tt.window}}, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
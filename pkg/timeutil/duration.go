@@ -0,0 +1,115 @@
+// Package timeutil parses the durations and times accepted by tool arguments.
+package timeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// units are the duration units, longest first so that "ms" is not read as
+// "m".
+var units = []struct {
+	name string
+	d    time.Duration
+}{
+	{"ms", time.Millisecond},
+	{"s", time.Second},
+	{"m", time.Minute},
+	{"h", time.Hour},
+	{"d", 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+	{"y", 365 * 24 * time.Hour},
+}
+
+// ParseDuration parses a duration in the Prometheus format: one or more
+// integers each followed by a unit, from years to milliseconds ('90s',
+// '1h30m', '1w', '2d12h'). Units are ms, s, m, h, d (24h), w (7d) and y
+// (365d). Go durations with fractions ('1.5h') are accepted as well.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	d, err := parsePromDuration(s)
+	if err == nil {
+		return d, nil
+	}
+	if d, goErr := time.ParseDuration(s); goErr == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, err
+}
+
+// parsePromDuration parses a duration in the Prometheus format.
+func parsePromDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty duration: expected a duration like '30m', '1h30m' or '1w'")
+	}
+	var total time.Duration
+	rest := s
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected a number before %q", s, rest)
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("invalid duration %q: missing unit after %s (use ms, s, m, h, d, w or y, e.g. '1h30m')", s, rest)
+		}
+		val, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s is too large", s, rest[:i])
+		}
+		rest = rest[i:]
+		unit := -1
+		for j, u := range units {
+			if strings.HasPrefix(rest, u.name) {
+				unit = j
+				break
+			}
+		}
+		if unit < 0 {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q (use ms, s, m, h, d, w or y, e.g. '1h30m')", s, strings.TrimLeft(rest, "0123456789"))
+		}
+		u := units[unit]
+		if val > int64((1<<63-1)/u.d) || total+time.Duration(val)*u.d < total {
+			return 0, fmt.Errorf("invalid duration %q: too long", s)
+		}
+		total += time.Duration(val) * u.d
+		rest = rest[len(u.name):]
+	}
+	return total, nil
+}
+
+// ParseLength parses how long something lasts from start: a duration as
+// accepted by ParseDuration ('1h30m'), or 'until' and a time as accepted by
+// ParseTime ('until 18:00', 'until Friday 09:00 Europe/Madrid', 'until
+// 2026-10-24T02:00:00Z'). A bare RFC3339 timestamp is also taken as the end.
+// The end must be after start.
+func ParseLength(s string, start time.Time, loc *time.Location) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	end, isTime := "", false
+	if rest, ok := cutWord(s, "until"); ok {
+		end, isTime = rest, true
+	} else if _, err := time.Parse(time.RFC3339, s); err == nil {
+		end, isTime = s, true
+	}
+	if !isTime {
+		return ParseDuration(s)
+	}
+	t, err := ParseTime(end, start, loc)
+	if err != nil {
+		return 0, err
+	}
+	if !t.After(start) {
+		return 0, fmt.Errorf("%q is not after the start %s", s, start.In(loc).Format(time.RFC3339))
+	}
+	return t.Sub(start), nil
+}
+
+// cutWord returns s without its first word if that word is word, ignoring
+// case.
+func cutWord(s, word string) (string, bool) {
+	if len(s) > len(word) && strings.EqualFold(s[:len(word)], word) && s[len(word)] == ' ' {
+		return strings.TrimSpace(s[len(word):]), true
+	}
+	return "", false
+}
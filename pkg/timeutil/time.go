@@ -0,0 +1,224 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var location atomic.Pointer[time.Location]
+
+// SetLocation sets the server-wide time zone of times given without one.
+func SetLocation(loc *time.Location) {
+	location.Store(loc)
+}
+
+// Location returns the server-wide time zone, the local time zone unless
+// set with SetLocation.
+func Location() *time.Location {
+	if loc := location.Load(); loc != nil {
+		return loc
+	}
+	return time.Local
+}
+
+// Days an expression can name.
+const (
+	anyDay = iota
+	today
+	tomorrow
+	weekday
+	date
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// expression is a day and time of day in a time zone, e.g.
+// "Saturday 02:00 Europe/Madrid".
+type expression struct {
+	day      int
+	weekday  time.Weekday
+	date     time.Time
+	hour     int
+	minute   int
+	hasClock bool
+	loc      *time.Location
+}
+
+// parseExpression parses the words of an expression in any order: a day
+// ('today', 'tomorrow', a weekday or a '2006-01-02' date), a time of day
+// ('15:04') and an IANA time zone ('Europe/Madrid', 'UTC'). Without a time
+// zone, loc is used.
+func parseExpression(s string, loc *time.Location) (expression, error) {
+	e := expression{loc: loc}
+	var hasZone bool
+	for _, word := range strings.Fields(s) {
+		lower := strings.ToLower(word)
+		setDay := func(day int) error {
+			if e.day != anyDay {
+				return fmt.Errorf("more than one day in %q", s)
+			}
+			e.day = day
+			return nil
+		}
+		var err error
+		if w, ok := weekdays[lower]; ok {
+			e.weekday = w
+			err = setDay(weekday)
+		} else if t, perr := time.Parse("15:04", word); perr == nil {
+			if e.hasClock {
+				return e, fmt.Errorf("more than one time of day in %q", s)
+			}
+			e.hour, e.minute, e.hasClock = t.Hour(), t.Minute(), true
+		} else if t, perr := time.Parse(time.DateOnly, word); perr == nil {
+			e.date = t
+			err = setDay(date)
+		} else if lower == "today" {
+			err = setDay(today)
+		} else if lower == "tomorrow" {
+			err = setDay(tomorrow)
+		} else if lower == "at" || lower == "on" || lower == "next" {
+			continue
+		} else if z, zerr := time.LoadLocation(word); zerr == nil && !hasZone {
+			e.loc, hasZone = z, true
+		} else {
+			return e, fmt.Errorf("unrecognized %q in %q: expected a day, a time of day like 02:00 or a time zone like Europe/Madrid", word, s)
+		}
+		if err != nil {
+			return e, err
+		}
+	}
+	if e.day == anyDay && !e.hasClock {
+		return e, fmt.Errorf("missing day or time of day in %q", s)
+	}
+	return e, nil
+}
+
+// next returns the first time after now the expression names; expressions
+// with a fixed day return that day even if it is not after now.
+func (e expression) next(now time.Time) time.Time {
+	local := now.In(e.loc)
+	at := func(day time.Time) time.Time {
+		y, m, d := day.Date()
+		return time.Date(y, m, d, e.hour, e.minute, 0, 0, e.loc)
+	}
+	switch e.day {
+	case date:
+		return at(e.date)
+	case today:
+		return at(local)
+	case tomorrow:
+		return at(local.AddDate(0, 0, 1))
+	case weekday:
+		for i := 0; ; i++ {
+			day := local.AddDate(0, 0, i)
+			if t := at(day); day.Weekday() == e.weekday && t.After(now) {
+				return t
+			}
+		}
+	default:
+		if t := at(local); t.After(now) {
+			return t
+		}
+		return at(local.AddDate(0, 0, 1))
+	}
+}
+
+// period returns the time between two times a weekly or daily expression
+// names.
+func (e expression) period() time.Duration {
+	if e.day == weekday {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// ParseTime parses a point in time: an RFC3339 timestamp
+// ('2026-10-24T02:00:00+02:00') or a day and time of day with an optional
+// time zone ('Saturday 02:00 Europe/Madrid', 'tomorrow 06:30', '2026-10-24
+// 02:00 UTC', '18:00'). A weekday or a time without a day is the next such
+// time after now. Without a time zone, loc is used.
+func ParseTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(s)); err == nil {
+		return t, nil
+	}
+	e, err := parseExpression(s, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return e.next(now), nil
+}
+
+// ParseWindow parses a time as accepted by ParseTime, optionally followed by
+// 'for' and a length as accepted by ParseLength, or by 'until' and an end
+// time: 'Saturday 02:00 Europe/Madrid for 3h', 'tomorrow 22:00 until 23:30'.
+// The duration is zero if not given.
+func ParseWindow(s string, now time.Time, loc *time.Location) (time.Time, time.Duration, error) {
+	start, length := s, ""
+	lower := strings.ToLower(s)
+	if i := strings.LastIndex(lower, " for "); i >= 0 {
+		start, length = s[:i], strings.TrimSpace(s[i+len(" for "):])
+	} else if i := strings.LastIndex(lower, " until "); i >= 0 {
+		start, length = s[:i], strings.TrimSpace(s[i+1:])
+	}
+	t, err := ParseTime(start, now, loc)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if length == "" {
+		return t, 0, nil
+	}
+	d, err := ParseLength(length, t, loc)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return t, d, nil
+}
+
+// Recurrence is a time of day repeating every day or every week on one
+// weekday, in a time zone.
+type Recurrence struct {
+	e expression
+}
+
+// ParseRecurrence parses a daily ('02:00 UTC') or weekly ('Saturday 02:00
+// Europe/Madrid') recurrence. Without a time zone, loc is used.
+func ParseRecurrence(s string, loc *time.Location) (Recurrence, error) {
+	e, err := parseExpression(s, loc)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	if e.day != anyDay && e.day != weekday {
+		return Recurrence{}, errors.New("a recurrence is a time of day, optionally on a weekday, e.g. 'Saturday 02:00 Europe/Madrid'")
+	}
+	if !e.hasClock {
+		return Recurrence{}, fmt.Errorf("missing time of day in %q", s)
+	}
+	return Recurrence{e}, nil
+}
+
+// Next returns the first occurrence after t.
+func (r Recurrence) Next(t time.Time) time.Time {
+	return r.e.next(t)
+}
+
+// Period returns the time between two occurrences, ignoring daylight saving
+// time changes.
+func (r Recurrence) Period() time.Duration {
+	return r.e.period()
+}
+
+// Later returns the later of two times.
+func Later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
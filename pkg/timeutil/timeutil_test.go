@@ -0,0 +1,154 @@
+package timeutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr string
+	}{
+		{in: "30m", want: 30 * time.Minute},
+		{in: "90s", want: 90 * time.Second},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "2d12h", want: 60 * time.Hour},
+		{in: "1w", want: 7 * 24 * time.Hour},
+		{in: "1y", want: 365 * 24 * time.Hour},
+		{in: "500ms", want: 500 * time.Millisecond},
+		{in: " 2h ", want: 2 * time.Hour},
+		{in: "1.5h", want: 90 * time.Minute},
+		{in: "", wantErr: "empty duration"},
+		{in: "1x", wantErr: `unknown unit "x"`},
+		{in: "30", wantErr: "missing unit"},
+		{in: "h", wantErr: "expected a number"},
+		{in: "-1h", wantErr: "expected a number"},
+		{in: "99999999999y", wantErr: "too long"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseDuration(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseDuration(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// now is Sunday 2026-10-18 10:00 UTC.
+var now = time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+func TestParseLength(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	tests := []struct {
+		in      string
+		loc     *time.Location
+		want    time.Duration
+		wantErr string
+	}{
+		{in: "1h30m", loc: time.UTC, want: 90 * time.Minute},
+		{in: "until 18:00", loc: time.UTC, want: 8 * time.Hour},
+		{in: "until 18:00", loc: madrid, want: 6 * time.Hour},
+		{in: "until 09:00", loc: time.UTC, want: 23 * time.Hour},
+		{in: "until tomorrow 09:00 UTC", loc: madrid, want: 23 * time.Hour},
+		{in: "until Friday 09:00", loc: time.UTC, want: 4*24*time.Hour + 23*time.Hour},
+		{in: "2026-10-19T00:00:00Z", loc: time.UTC, want: 14 * time.Hour},
+		{in: "until 2026-10-17T00:00:00Z", loc: time.UTC, wantErr: "is not after the start"},
+		{in: "until today 09:00", loc: time.UTC, wantErr: "is not after the start"},
+		{in: "until soon", loc: time.UTC, wantErr: `unrecognized "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLength(tt.in, now, tt.loc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseLength(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseLength(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	tests := []struct {
+		in        string
+		wantStart time.Time
+		wantLen   time.Duration
+		wantErr   string
+	}{
+		{in: "Saturday 02:00 Europe/Madrid for 3h", wantStart: time.Date(2026, 10, 24, 2, 0, 0, 0, madrid), wantLen: 3 * time.Hour},
+		{in: "tomorrow 22:00 until 23:30", wantStart: time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC), wantLen: 90 * time.Minute},
+		{in: "2026-10-24 02:00 UTC", wantStart: time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC)},
+		{in: "2026-10-24T02:00:00Z for 1w", wantStart: time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC), wantLen: 7 * 24 * time.Hour},
+		{in: "09:00", wantStart: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{in: "Sunday 09:00", wantStart: time.Date(2026, 10, 25, 9, 0, 0, 0, time.UTC)},
+		{in: "Saturday Sunday 02:00", wantErr: "more than one day"},
+		{in: "Saturday for 3x", wantErr: `unknown unit "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			start, length, err := ParseWindow(tt.in, now, time.UTC)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseWindow(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !start.Equal(tt.wantStart) || length != tt.wantLen {
+				t.Fatalf("ParseWindow(%q) = %v, %v, %v, want %v, %v", tt.in, start, length, err, tt.wantStart, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestRecurrence(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantNext   time.Time
+		wantPeriod time.Duration
+		wantErr    string
+	}{
+		{in: "03:00 UTC", wantNext: time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), wantPeriod: 24 * time.Hour},
+		{in: "Saturday 02:00", wantNext: time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC), wantPeriod: 7 * 24 * time.Hour},
+		{in: "Sunday 12:00", wantNext: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), wantPeriod: 7 * 24 * time.Hour},
+		{in: "tomorrow 02:00", wantErr: "a recurrence is a time of day"},
+		{in: "Saturday", wantErr: "missing time of day"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.in, time.UTC)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseRecurrence(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.Next(now); !got.Equal(tt.wantNext) || r.Period() != tt.wantPeriod {
+				t.Fatalf("ParseRecurrence(%q): Next = %v, Period = %v, want %v, %v", tt.in, got, r.Period(), tt.wantNext, tt.wantPeriod)
+			}
+		})
+	}
+}
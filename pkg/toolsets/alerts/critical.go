@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+type getCriticalAlertsArgs struct {
+	output.PageArgs
+	output.FieldsArgs
+	output.FormatArgs
+}
+
 func registerGetCriticalAlerts(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getCriticalAlertsArgs](output.PageSchema(alertSortKeys))
 	s.AddTool(&mcp.Tool{
 		Name:        "getCriticalAlerts",
 		Description: "Get critical severity alerts only. Prioritized for incident response.",
@@ -19,14 +26,22 @@ func registerGetCriticalAlerts(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Alerts: Get Critical Alerts",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[output.List[alertmanager.GettableAlert]](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		result, err := client.GetAlerts("true", "", "", `severity="critical"`)
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "", "", `severity="critical"`)
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get critical alerts: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+		result, list, err := listAlerts(alerts, args.Page(), args.Fields, args.Format)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format alerts: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, list), nil
 	})
 }
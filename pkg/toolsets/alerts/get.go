@@ -3,12 +3,13 @@ package alerts
 import (
 	"context"
 	"fmt"
+	"strconv"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
 // Register registers all alert-related tools.
@@ -19,7 +20,21 @@ func Register(s *mcp.Server, client *alertmanager.Client) {
 	registerGetAlertingSummary(s, client)
 }
 
+// alertSortKeys documents the sortBy values accepted by alert list tools.
+const alertSortKeys = "startsAt, endsAt, updatedAt, severity, state, fingerprint, or any label name (e.g. alertname, namespace)"
+
+type getAlertsArgs struct {
+	Active      *bool  `json:"active,omitempty" jsonschema:"Include active alerts (default: true)"`
+	Silenced    *bool  `json:"silenced,omitempty" jsonschema:"Include silenced alerts (default: true)"`
+	Inhibited   *bool  `json:"inhibited,omitempty" jsonschema:"Include inhibited alerts (default: true)"`
+	FilterLabel string `json:"filterLabel,omitempty" jsonschema:"Label matcher, e.g. 'severity=critical' or 'namespace=~team-.*'"`
+	output.PageArgs
+	output.FieldsArgs
+	output.FormatArgs
+}
+
 func registerGetAlerts(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getAlertsArgs](output.PageSchema(alertSortKeys))
 	s.AddTool(&mcp.Tool{
 		Name:        "getAlerts",
 		Description: "Get alerts from Alertmanager. Returns active alerts by default. Filter by: active, silenced, inhibited, or label (e.g., 'severity=critical').",
@@ -27,42 +42,55 @@ func registerGetAlerts(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Alerts: Get Alerts",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"active": {
-					Type:        "string",
-					Description: "Include active alerts (true/false)",
-				},
-				"silenced": {
-					Type:        "string",
-					Description: "Include silenced alerts (true/false)",
-				},
-				"inhibited": {
-					Type:        "string",
-					Description: "Include inhibited alerts (true/false)",
-				},
-				"filterLabel": {
-					Type:        "string",
-					Description: "Label filter: 'key=value'",
-				},
-			},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[output.List[alertmanager.GettableAlert]](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
+		if args.FilterLabel != "" {
+			if _, err := alertmanager.ParseMatcher(args.FilterLabel); err != nil {
+				return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"filterLabel\": %v", err)), nil
+			}
+		}
 
-		active, _ := args["active"].(string)
-		silenced, _ := args["silenced"].(string)
-		inhibited, _ := args["inhibited"].(string)
-		filterLabel, _ := args["filterLabel"].(string)
-
-		result, err := client.GetAlerts(active, silenced, inhibited, filterLabel)
+		alerts, err := client.GetAlertsRaw(boolParam(args.Active), boolParam(args.Silenced), boolParam(args.Inhibited), args.FilterLabel)
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+		result, list, err := listAlerts(alerts, args.Page(), args.Fields, args.Format)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format alerts: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, list), nil
+	})
+}
+
+// boolParam formats an optional boolean as an API query parameter.
+func boolParam(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// listAlerts sorts and paginates alerts and renders the visible fields. It
+// also returns the returned page as structured content.
+func listAlerts(alerts []alertmanager.GettableAlert, page output.Page, fields []string, format output.Format) (string, output.List[alertmanager.GettableAlert], error) {
+	var list output.List[alertmanager.GettableAlert]
+	if err := output.SortAlerts(alerts, page.SortBy); err != nil {
+		return "", list, err
+	}
+	text, err := output.Paginate(alerts, page, "alerts", format, func(items []alertmanager.GettableAlert) (string, any, error) {
+		list = output.List[alertmanager.GettableAlert]{
+			Items:  output.Alerts(items, fields),
+			Total:  len(alerts),
+			Offset: page.Offset,
+		}
+		return output.RenderPage(format, list, output.Report{
+			Tables: []output.Table{output.AlertsTable("", list.Items)},
+		})
 	})
+	return text, list, err
 }
@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+type getAlertGroupsArgs struct {
+	output.PageArgs
+	output.FieldsArgs
+	output.FormatArgs
+}
+
 func registerGetAlertGroups(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getAlertGroupsArgs](output.PageSchema("size, receiver, or any group label name"))
 	s.AddTool(&mcp.Tool{
 		Name:        "getAlertGroups",
 		Description: "Get alerts grouped by routing labels. Shows how alerts are batched for notifications.",
@@ -19,14 +26,36 @@ func registerGetAlertGroups(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Alerts: Get Alert Groups",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[output.List[alertmanager.AlertGroup]](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		result, err := client.GetAlertGroups()
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		page := args.Page()
+
+		groups, err := client.GetAlertGroupsRaw()
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alert groups: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+		if err := output.SortAlertGroups(groups, page.SortBy); err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		var list output.List[alertmanager.AlertGroup]
+		result, err := output.Paginate(groups, page, "alert groups", args.Format, func(items []alertmanager.AlertGroup) (string, any, error) {
+			list = output.List[alertmanager.AlertGroup]{
+				Items:  output.AlertGroups(items, args.Fields),
+				Total:  len(groups),
+				Offset: page.Offset,
+			}
+			return output.RenderPage(args.Format, list, output.Report{
+				Tables: []output.Table{output.AlertGroupsTable("", list.Items)},
+			})
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format alert groups: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, list), nil
 	})
 }
@@ -3,17 +3,28 @@ package alerts
 import (
 	"context"
 	"fmt"
-	"sort"
-	"strings"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+// alertingSummary is the structured form of the alerting summary.
+type alertingSummary struct {
+	Total      int            `json:"total" jsonschema:"number of active alerts"`
+	BySeverity map[string]int `json:"bySeverity,omitempty" jsonschema:"active alerts per severity; absent when the severity label is hidden"`
+	ByAlert    map[string]int `json:"byAlert" jsonschema:"active instances per alert name"`
+	Namespaces map[string]int `json:"namespaces,omitempty" jsonschema:"active alerts per namespace; absent when the namespace label is hidden"`
+}
+
+type getAlertingSummaryArgs struct {
+	output.FormatArgs
+}
+
 func registerGetAlertingSummary(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getAlertingSummaryArgs]()
 	s.AddTool(&mcp.Tool{
 		Name:        "getAlertingSummary",
 		Description: "Get alerting summary: counts by severity, top alerts, affected namespaces.",
@@ -21,10 +32,14 @@ func registerGetAlertingSummary(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Alerts: Get Alerting Summary",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[alertingSummary](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
 		alerts, err := client.GetAlertsRaw("true", "", "")
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
@@ -51,45 +66,33 @@ func registerGetAlertingSummary(s *mcp.Server, client *alertmanager.Client) {
 			}
 		}
 
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("=== Alerting Summary ===\nTotal Active Alerts: %d\n\n", len(alerts)))
-
-		sb.WriteString("--- By Severity ---\n")
-		for sev, count := range severityCounts {
-			sb.WriteString(fmt.Sprintf("  %s: %d\n", sev, count))
-		}
-
-		sb.WriteString("\n--- Top Alerts ---\n")
-		type kv struct {
-			Key   string
-			Value int
+		topAlerts := output.CountTable("Top Alerts", "alertname", "instances", alertCounts)
+		if len(topAlerts.Rows) > 10 {
+			topAlerts.Rows = topAlerts.Rows[:10]
 		}
-		var sortedAlerts []kv
-		for k, v := range alertCounts {
-			sortedAlerts = append(sortedAlerts, kv{k, v})
+		summary := alertingSummary{
+			Total:   len(alerts),
+			ByAlert: alertCounts,
 		}
-		sort.Slice(sortedAlerts, func(i, j int) bool {
-			return sortedAlerts[i].Value > sortedAlerts[j].Value
-		})
-		for i, kv := range sortedAlerts {
-			if i >= 10 {
-				break
-			}
-			sb.WriteString(fmt.Sprintf("  %s: %d instances\n", kv.Key, kv.Value))
+		var tables []output.Table
+		// Leave out the counts of labels hidden from the client
+		if output.LabelVisible("severity", nil) {
+			summary.BySeverity = severityCounts
+			tables = append(tables, output.CountTable("By Severity", "severity", "alerts", severityCounts))
 		}
-
-		sb.WriteString("\n--- Affected Namespaces ---\n")
-		var sortedNs []kv
-		for k, v := range namespaceCounts {
-			sortedNs = append(sortedNs, kv{k, v})
+		tables = append(tables, topAlerts)
+		if output.LabelVisible("namespace", nil) {
+			summary.Namespaces = namespaceCounts
+			tables = append(tables, output.CountTable("Affected Namespaces", "namespace", "alerts", namespaceCounts))
 		}
-		sort.Slice(sortedNs, func(i, j int) bool {
-			return sortedNs[i].Value > sortedNs[j].Value
+		result, err := output.Render(args.Format, summary, output.Report{
+			Title:  "Alerting Summary",
+			Notes:  []string{fmt.Sprintf("Total Active Alerts: %d", len(alerts))},
+			Tables: tables,
 		})
-		for _, kv := range sortedNs {
-			sb.WriteString(fmt.Sprintf("  %s: %d alerts\n", kv.Key, kv.Value))
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format summary: %v", err)), nil
 		}
-
-		return mcputil.NewTextResult(sb.String()), nil
+		return mcputil.NewStructuredResult(result, summary), nil
 	})
 }
@@ -0,0 +1,41 @@
+// Package analytics provides tools that analyze alert history: flapping
+// alerts, alerting KPIs and noise.
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+// noHistory is the error of analytics tools when alert history is disabled.
+const noHistory = "Alert history is not enabled: start the server with --history-file to record it, or --prometheus-url to read it from the Prometheus ALERTS series."
+
+// Register registers all analytics tools. source is the alert history; nil if
+// history is disabled, in which case the tools explain how to enable it.
+func Register(s *mcp.Server, client *alertmanager.Client, source history.Source) {
+	registerDetectFlappingAlerts(s, source)
+	registerGetAlertingReport(s, source)
+	registerAnalyzeAlertNoise(s, client)
+}
+
+// parseWindow parses a window argument, applying the default, and returns
+// the window with its start. Windows are at least a minute long.
+func parseWindow(window, defaultWindow string, now time.Time) (string, time.Time, error) {
+	if window == "" {
+		window = defaultWindow
+	}
+	d, err := timeutil.ParseDuration(window)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid argument \"window\": %v", err)
+	}
+	if d < time.Minute {
+		return "", time.Time{}, fmt.Errorf("invalid argument \"window\": %q is shorter than a minute", window)
+	}
+	return window, now.Add(-d), nil
+}
@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+)
+
+var now = time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantSince time.Time
+		wantErr   string
+	}{
+		{in: "", wantSince: now.Add(-30 * 24 * time.Hour)},
+		{in: "2w", wantSince: now.Add(-14 * 24 * time.Hour)},
+		{in: "1m", wantSince: now.Add(-time.Minute)},
+		{in: "0s", wantErr: `"0s" is shorter than a minute`},
+		{in: "30s", wantErr: `"30s" is shorter than a minute`},
+		{in: "soon", wantErr: `invalid argument "window"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, since, err := parseWindow(tt.in, "30d", now)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseWindow(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !since.Equal(tt.wantSince) {
+				t.Errorf("parseWindow(%q) = %v, %v, want %v", tt.in, since, err, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestWeeklyVolume(t *testing.T) {
+	occurrence := func(ago time.Duration) history.Occurrence {
+		end := now.Add(-ago + time.Hour)
+		return history.Occurrence{StartsAt: now.Add(-ago), EndsAt: &end}
+	}
+	tests := []struct {
+		name        string
+		since       time.Time
+		occurrences []history.Occurrence
+		want        []int
+	}{
+		{"two weeks", now.Add(-2 * week), []history.Occurrence{occurrence(time.Hour), occurrence(8 * 24 * time.Hour), occurrence(20 * 24 * time.Hour)}, []int{1, 1}},
+		{"partial week", now.Add(-10 * 24 * time.Hour), []history.Occurrence{occurrence(9 * 24 * time.Hour)}, []int{0, 1}},
+		{"empty window", now, []history.Occurrence{occurrence(0)}, nil},
+		{"start after now", now.Add(-week), []history.Occurrence{occurrence(-time.Hour)}, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks := weeklyVolume(tt.occurrences, tt.since, now)
+			var got []int
+			for _, w := range weeks {
+				got = append(got, w.Fired)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("weeklyVolume() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("weeklyVolume() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
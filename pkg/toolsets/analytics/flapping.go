@@ -0,0 +1,264 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+const (
+	defaultMinFlaps = 3
+	// shortEpisode and shortGap are the median firing time and time between
+	// firings below which tuning for: or keep_firing_for: is suggested.
+	shortEpisode = 15 * time.Minute
+	shortGap     = 30 * time.Minute
+)
+
+type detectFlappingAlertsArgs struct {
+	AlertName string `json:"alertName,omitempty" jsonschema:"Only check this alert (default: all alerts)"`
+	Window    string `json:"window,omitempty" jsonschema:"How far back to look: '6h', '1d12h', '1w' (default: 24h)"`
+	MinFlaps  int    `json:"minFlaps,omitempty"`
+	output.PageArgs
+	output.FormatArgs
+}
+
+func registerDetectFlappingAlerts(s *mcp.Server, source history.Source) {
+	input := mcputil.NewInput[detectFlappingAlertsArgs](
+		output.PageSchema("transitions, flaps, medianFiring, medianGap, or any label name (default: -transitions)"),
+		mcputil.Describe("minFlaps", fmt.Sprintf("Report label sets that fired and resolved more than this many times in the window (default: %d)", defaultMinFlaps)),
+		mcputil.Minimum("minFlaps", 1),
+	)
+	s.AddTool(&mcp.Tool{
+		Name:        "detectFlappingAlerts",
+		Description: "Find flapping alerts: label sets that fired and resolved repeatedly in a time window, ranked by state transitions, with suggested for: or keep_firing_for: (hysteresis) changes. Requires alert history (history file or Prometheus).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Analytics: Detect Flapping Alerts",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[flappingReport](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		if source == nil {
+			return mcputil.NewErrorResult(noHistory), nil
+		}
+
+		now := time.Now()
+		window, since, err := parseWindow(args.Window, "24h", now)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		minFlaps := args.MinFlaps
+		if minFlaps == 0 {
+			minFlaps = defaultMinFlaps
+		}
+		page := args.Page()
+		if page.SortBy == "" {
+			page.SortBy = "-transitions"
+		}
+
+		occurrences, err := source.Occurrences(ctx, args.AlertName, since)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alert history: %v", err)), nil
+		}
+
+		var flapping []flappingAlert
+		for _, group := range history.ByFingerprint(occurrences) {
+			if f := detectFlapping(group, since, now); f.Flaps > minFlaps {
+				flapping = append(flapping, f)
+			}
+		}
+		sort.Slice(flapping, func(i, j int) bool { return flapping[i].Fingerprint < flapping[j].Fingerprint })
+		err = output.Sort(flapping, page.SortBy, func(key string) func(a, b flappingAlert) int {
+			switch key {
+			case "transitions":
+				return func(a, b flappingAlert) int { return cmp.Compare(a.Transitions, b.Transitions) }
+			case "flaps":
+				return func(a, b flappingAlert) int { return cmp.Compare(a.Flaps, b.Flaps) }
+			case "medianFiring":
+				return func(a, b flappingAlert) int { return cmp.Compare(a.MedianFiringSeconds, b.MedianFiringSeconds) }
+			case "medianGap":
+				return func(a, b flappingAlert) int { return cmp.Compare(a.MedianGapSeconds, b.MedianGapSeconds) }
+			default:
+				return func(a, b flappingAlert) int { return cmp.Compare(a.Labels[key], b.Labels[key]) }
+			}
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		notes := []string{
+			fmt.Sprintf("Last %s: %d label set(s) fired and resolved more than %d time(s).", window, len(flapping), minFlaps),
+			"Source: " + source.Name(),
+		}
+		if recorded := source.RecordedSince(); recorded.After(since) {
+			notes = append(notes, fmt.Sprintf("History is only recorded since %s, so the window is not fully covered.", output.FormatTime(recorded)))
+		}
+
+		var data flappingReport
+		result, err := output.Paginate(flapping, page, "flapping alerts", args.Format, func(items []flappingAlert) (string, any, error) {
+			for i := range items {
+				items[i].Labels = output.Labels(items[i].Labels, nil)
+			}
+			data = flappingReport{
+				Window:   window,
+				Since:    since,
+				Source:   source.Name(),
+				MinFlaps: minFlaps,
+				Total:    len(flapping),
+				Offset:   page.Offset,
+				Items:    items,
+			}
+			report := output.Report{Title: "Flapping Alerts", Notes: notes}
+			if len(flapping) == 0 {
+				report.Notes = append(report.Notes, "No flapping alerts found.")
+			} else {
+				report.Tables = flappingTables(items)
+			}
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format flapping alerts: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// flappingReport is the structured result of detectFlappingAlerts.
+type flappingReport struct {
+	Window   string          `json:"window"`
+	Since    time.Time       `json:"since" jsonschema:"start of the window"`
+	Source   string          `json:"source" jsonschema:"where the history comes from"`
+	MinFlaps int             `json:"minFlaps"`
+	Total    int             `json:"total" jsonschema:"number of flapping label sets across all pages"`
+	Offset   int             `json:"offset" jsonschema:"index of the first returned label set"`
+	Items    []flappingAlert `json:"items"`
+}
+
+// flappingAlert is a label set that fired and resolved repeatedly.
+type flappingAlert struct {
+	Fingerprint            string            `json:"fingerprint"`
+	Labels                 map[string]string `json:"labels"`
+	Flaps                  int               `json:"flaps" jsonschema:"times it fired and resolved in the window"`
+	Transitions            int               `json:"transitions" jsonschema:"state transitions in the window"`
+	FiringNow              bool              `json:"firingNow"`
+	MedianFiringSeconds    int64             `json:"medianFiringSeconds" jsonschema:"median time from firing to resolving"`
+	MedianGapSeconds       int64             `json:"medianGapSeconds" jsonschema:"median time from resolving to firing again"`
+	IncreaseForBy          string            `json:"increaseForBy,omitempty" jsonschema:"how much longer the rule's for: would have to be to keep most short episodes from firing"`
+	SuggestedKeepFiringFor string            `json:"suggestedKeepFiringFor,omitempty" jsonschema:"keep_firing_for: duration that would have merged most re-fires"`
+	Suggestion             string            `json:"suggestion"`
+}
+
+// detectFlapping measures the flapping of one label set from its occurrences,
+// ordered by start time, and suggests how to damp it.
+func detectFlapping(occurrences []history.Occurrence, since, now time.Time) flappingAlert {
+	last := occurrences[len(occurrences)-1]
+	f := flappingAlert{
+		Fingerprint: last.Fingerprint,
+		Labels:      last.Labels,
+		FiringNow:   last.Open(),
+	}
+	var episodes []time.Duration
+	for _, o := range occurrences {
+		for _, t := range o.Transitions {
+			if !t.Time.Before(since) {
+				f.Transitions++
+			}
+		}
+		if o.EndsAt != nil && !o.EndsAt.Before(since) {
+			f.Flaps++
+			episodes = append(episodes, o.Duration(now))
+		}
+	}
+	gaps := history.Gaps(occurrences)
+	medianEpisode := history.Percentile(episodes, 50)
+	medianGap := history.Percentile(gaps, 50)
+	f.MedianFiringSeconds = int64(medianEpisode.Seconds())
+	f.MedianGapSeconds = int64(medianGap.Seconds())
+
+	var suggestions []string
+	if len(episodes) > 0 && medianEpisode < shortEpisode {
+		d := ceilMinute(history.Percentile(episodes, 75))
+		f.IncreaseForBy = promDuration(d)
+		suggestions = append(suggestions, fmt.Sprintf("Episodes are short (median %s): increasing for: by about %s would have kept %d of %d from firing.",
+			output.FormatDuration(medianEpisode), f.IncreaseForBy, countBelow(episodes, d), len(episodes)))
+	}
+	if len(gaps) > 0 && medianGap < shortGap {
+		d := ceilMinute(history.Percentile(gaps, 75))
+		f.SuggestedKeepFiringFor = promDuration(d)
+		suggestions = append(suggestions, fmt.Sprintf("It fires again soon after resolving (median %s): keep_firing_for: %s, or a resolve threshold below the firing threshold, would have merged %d of %d re-fires.",
+			output.FormatDuration(medianGap), f.SuggestedKeepFiringFor, countBelow(gaps, d+1), len(gaps)))
+	}
+	if len(suggestions) == 0 {
+		suggestions = append(suggestions, fmt.Sprintf("Episodes (median %s) and gaps (median %s) are long: review the threshold or fix the underlying cause rather than damping the rule.",
+			output.FormatDuration(medianEpisode), output.FormatDuration(medianGap)))
+	}
+	f.Suggestion = strings.Join(suggestions, " ")
+	return f
+}
+
+// countBelow returns the number of durations shorter than d.
+func countBelow(durations []time.Duration, d time.Duration) int {
+	n := 0
+	for _, v := range durations {
+		if v < d {
+			n++
+		}
+	}
+	return n
+}
+
+// ceilMinute rounds d up to a whole number of minutes, at least one.
+func ceilMinute(d time.Duration) time.Duration {
+	return max(((d+time.Minute-1)/time.Minute)*time.Minute, time.Minute)
+}
+
+// promDuration formats d in Prometheus duration syntax, e.g. "5m" or "1h30m".
+func promDuration(d time.Duration) string {
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h == 0:
+		return strconv.Itoa(m) + "m"
+	case m == 0:
+		return strconv.Itoa(h) + "h"
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+func flappingTables(items []flappingAlert) []output.Table {
+	t := output.Table{
+		Columns: []string{"fingerprint", "alertname", "flaps", "transitions", "medianFiring", "medianGap", "increaseForBy", "keepFiringFor", "labels"},
+	}
+	for _, f := range items {
+		t.Rows = append(t.Rows, []string{
+			f.Fingerprint,
+			f.Labels["alertname"],
+			strconv.Itoa(f.Flaps),
+			strconv.Itoa(f.Transitions),
+			output.FormatSeconds(f.MedianFiringSeconds),
+			output.FormatSeconds(f.MedianGapSeconds),
+			f.IncreaseForBy,
+			f.SuggestedKeepFiringFor,
+			output.FormatLabels(f.Labels, "alertname"),
+		})
+	}
+	suggestions := output.Table{Title: "Suggestions", Columns: []string{"fingerprint", "alertname", "suggestion"}}
+	for _, f := range items {
+		suggestions.Rows = append(suggestions.Rows, []string{f.Fingerprint, f.Labels["alertname"], f.Suggestion})
+	}
+	return []output.Table{t, suggestions}
+}
@@ -0,0 +1,321 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+const (
+	defaultMaxInstances = 100
+	// permanentSilence is the length from which a silence is treated as
+	// permanent rather than a maintenance window.
+	permanentSilence = 30 * 24 * time.Hour
+)
+
+// requiredAnnotations are the annotations every alert should have.
+var requiredAnnotations = []string{"summary", "description", "runbook_url"}
+
+// Noise issues, in the order they are checked.
+const (
+	issueHighCardinality   = "high-cardinality"
+	issueLongFiring        = "long-firing"
+	issuePermanentSilence  = "permanently-silenced"
+	issueMissingSeverity   = "missing-severity"
+	issueMissingAnnotation = "missing-annotations"
+)
+
+type analyzeAlertNoiseArgs struct {
+	LongFiring   string `json:"longFiring,omitempty" jsonschema:"Alerts firing longer than this are reported: '1d', '72h', '7d' (default: 3d)"`
+	MaxInstances int    `json:"maxInstances,omitempty"`
+	output.PageArgs
+	output.FormatArgs
+}
+
+func registerAnalyzeAlertNoise(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[analyzeAlertNoiseArgs](
+		output.PageSchema("priority, instances, alertname, or issue (default: priority)"),
+		mcputil.Describe("maxInstances", fmt.Sprintf("Alert names with at least this many instances are reported (default: %d)", defaultMaxInstances)),
+		mcputil.Minimum("maxInstances", 2),
+	)
+	s.AddTool(&mcp.Tool{
+		Name:        "analyzeAlertNoise",
+		Description: "Lint current alerts and silences for noise and quality problems: alert names with hundreds of instances, alerts firing for days, permanently silenced alerts, missing severity label, missing summary/description/runbook_url annotations. Returns a prioritized cleanup list per alert name.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Analytics: Analyze Alert Noise",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[noiseReport](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		longFiring := args.LongFiring
+		if longFiring == "" {
+			longFiring = "3d"
+		}
+		longFiringFor, err := timeutil.ParseDuration(longFiring)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"longFiring\": %v", err)), nil
+		}
+		maxInstances := args.MaxInstances
+		if maxInstances == 0 {
+			maxInstances = defaultMaxInstances
+		}
+		page := args.Page()
+		if page.SortBy == "" {
+			page.SortBy = "priority"
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+		silences, err := client.GetSilences("active")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get silences: %v", err)), nil
+		}
+
+		findings := analyzeNoise(alerts, silences, longFiring, longFiringFor, maxInstances, time.Now())
+		err = output.Sort(findings, page.SortBy, func(key string) func(a, b noiseFinding) int {
+			switch key {
+			case "priority":
+				return func(a, b noiseFinding) int {
+					return cmp.Compare(output.PriorityRank(a.Priority), output.PriorityRank(b.Priority))
+				}
+			case "instances":
+				return func(a, b noiseFinding) int { return cmp.Compare(a.Instances, b.Instances) }
+			case "alertname":
+				return func(a, b noiseFinding) int { return cmp.Compare(a.AlertName, b.AlertName) }
+			case "issue":
+				return func(a, b noiseFinding) int { return cmp.Compare(a.Issue, b.Issue) }
+			default:
+				return nil
+			}
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		counts := make(map[string]int)
+		for _, f := range findings {
+			counts[f.Priority]++
+		}
+		notes := []string{
+			fmt.Sprintf("Checked %d alert(s) and %d active silence(s): %d finding(s), %d high, %d medium, %d low priority.",
+				len(alerts), len(silences), len(findings), counts["high"], counts["medium"], counts["low"]),
+		}
+
+		var data noiseReport
+		result, err := output.Paginate(findings, page, "findings", args.Format, func(items []noiseFinding) (string, any, error) {
+			data = noiseReport{
+				Alerts:   len(alerts),
+				Silences: len(silences),
+				Total:    len(findings),
+				Offset:   page.Offset,
+				Findings: items,
+			}
+			report := output.Report{Title: "Alert Noise Analysis", Notes: notes}
+			if len(findings) == 0 {
+				report.Notes = append(report.Notes, "No noise or quality problems found.")
+			} else {
+				report.Tables = []output.Table{noiseTable(items)}
+			}
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format findings: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// noiseReport is the structured result of analyzeAlertNoise.
+type noiseReport struct {
+	Alerts   int            `json:"alerts" jsonschema:"number of alerts checked"`
+	Silences int            `json:"silences" jsonschema:"number of active silences checked"`
+	Total    int            `json:"total" jsonschema:"number of findings across all pages"`
+	Offset   int            `json:"offset" jsonschema:"index of the first returned finding"`
+	Findings []noiseFinding `json:"findings"`
+}
+
+// noiseFinding is a noise or quality problem of an alert name.
+type noiseFinding struct {
+	Priority       string `json:"priority" jsonschema:"high, medium or low"`
+	Issue          string `json:"issue" jsonschema:"high-cardinality, long-firing, permanently-silenced, missing-severity or missing-annotations"`
+	AlertName      string `json:"alertName"`
+	Instances      int    `json:"instances" jsonschema:"number of alert instances with the problem"`
+	Detail         string `json:"detail"`
+	Recommendation string `json:"recommendation"`
+}
+
+// analyzeNoise checks the alerts of each alert name and returns the findings,
+// ordered by alert name and issue.
+func analyzeNoise(alerts []alertmanager.GettableAlert, silences []alertmanager.GettableSilence, window string, longFiring time.Duration, maxInstances int, now time.Time) []noiseFinding {
+	byID := make(map[string]alertmanager.GettableSilence, len(silences))
+	for _, s := range silences {
+		byID[s.ID] = s
+	}
+	byName := make(map[string][]alertmanager.GettableAlert)
+	for _, a := range alerts {
+		byName[a.Labels["alertname"]] = append(byName[a.Labels["alertname"]], a)
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []noiseFinding
+	for _, name := range names {
+		instances := byName[name]
+		severity := instances[0].Labels["severity"]
+		add := func(priority, issue string, n int, detail, recommendation string) {
+			findings = append(findings, noiseFinding{priority, issue, name, n, detail, recommendation})
+		}
+
+		if len(instances) >= maxInstances {
+			add("high", issueHighCardinality, len(instances),
+				fmt.Sprintf("%d instances; labels with the most distinct values: %s", len(instances), varyingLabels(instances)),
+				"Aggregate the alert expression (e.g. sum by (namespace)) or alert on a ratio, so one problem is one alert.")
+		}
+
+		var long, silenced, noSeverity int
+		var oldest time.Time
+		var longestSilence time.Duration
+		missing := make(map[string]int)
+		for _, a := range instances {
+			// Alerts of severity none, like Watchdog, fire all the time by design.
+			if a.Status.State == "active" && a.Labels["severity"] != "none" && now.Sub(a.StartsAt) >= longFiring {
+				long++
+				if oldest.IsZero() || a.StartsAt.Before(oldest) {
+					oldest = a.StartsAt
+				}
+			}
+			for _, id := range a.Status.SilencedBy {
+				s, ok := byID[id]
+				if !ok {
+					continue
+				}
+				if d := s.EndsAt.Sub(s.StartsAt); d >= permanentSilence || s.EndsAt.Sub(now) >= permanentSilence {
+					silenced++
+					longestSilence = max(longestSilence, d)
+					break
+				}
+			}
+			if a.Labels["severity"] == "" {
+				noSeverity++
+			}
+			for _, key := range requiredAnnotations {
+				if a.Annotations[key] == "" {
+					missing[key]++
+				}
+			}
+		}
+
+		if long > 0 {
+			priority := "medium"
+			if severity == "critical" {
+				priority = "high"
+			}
+			add(priority, issueLongFiring, long,
+				fmt.Sprintf("%d instance(s) firing for more than %s, the oldest for %s", long, window, output.FormatDuration(now.Sub(oldest))),
+				"Fix the cause; if nobody acts on it, lower its severity, raise the threshold or delete the rule.")
+		}
+		if silenced > 0 {
+			add("medium", issuePermanentSilence, silenced,
+				fmt.Sprintf("%d instance(s) silenced by silences of up to %s", silenced, output.FormatDuration(longestSilence)),
+				"Delete or fix the rule, or route it to a receiver nobody is paged by, instead of silencing it indefinitely.")
+		}
+		if noSeverity > 0 {
+			add("medium", issueMissingSeverity, noSeverity,
+				fmt.Sprintf("%d instance(s) without a severity label", noSeverity),
+				"Add a severity label (critical, warning or info) so routing and paging work as intended.")
+		}
+		if len(missing) > 0 {
+			var keys []string
+			n := 0
+			for _, key := range requiredAnnotations {
+				if missing[key] > 0 {
+					keys = append(keys, key)
+					n = max(n, missing[key])
+				}
+			}
+			priority := "low"
+			if severity == "critical" && missing["runbook_url"] > 0 {
+				priority = "medium"
+			}
+			add(priority, issueMissingAnnotation, n,
+				"missing "+strings.Join(keys, ", "),
+				"Add the missing annotations so responders know what is wrong and what to do.")
+		}
+	}
+	return findings
+}
+
+// varyingLabels returns the labels whose values differ most across instances,
+// e.g. "pod (250), container (3)".
+func varyingLabels(alerts []alertmanager.GettableAlert) string {
+	values := make(map[string]map[string]bool)
+	for _, a := range alerts {
+		for k, v := range a.Labels {
+			if values[k] == nil {
+				values[k] = make(map[string]bool)
+			}
+			values[k][v] = true
+		}
+	}
+	type label struct {
+		name   string
+		values int
+	}
+	var labels []label
+	for k, v := range values {
+		if len(v) > 1 && output.LabelVisible(k, nil) {
+			labels = append(labels, label{k, len(v)})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].values != labels[j].values {
+			return labels[i].values > labels[j].values
+		}
+		return labels[i].name < labels[j].name
+	})
+	parts := make([]string, 0, 3)
+	for _, l := range labels[:min(len(labels), 3)] {
+		parts = append(parts, fmt.Sprintf("%s (%d)", l.name, l.values))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func noiseTable(findings []noiseFinding) output.Table {
+	t := output.Table{
+		Title:   "Cleanup List",
+		Columns: []string{"priority", "issue", "alertname", "instances", "detail", "recommendation"},
+	}
+	for _, f := range findings {
+		t.Rows = append(t.Rows, []string{
+			f.Priority,
+			f.Issue,
+			f.AlertName,
+			strconv.Itoa(f.Instances),
+			f.Detail,
+			f.Recommendation,
+		})
+	}
+	return t
+}
@@ -0,0 +1,337 @@
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+const week = 7 * 24 * time.Hour
+
+type getAlertingReportArgs struct {
+	Window  string `json:"window,omitempty" jsonschema:"Period to report on: '1w', '30d', '12w' (default: 30d)"`
+	GroupBy string `json:"groupBy,omitempty" jsonschema:"Label to group by, e.g. alertname, namespace or severity (default: alertname)"`
+	output.PageArgs
+	output.FormatArgs
+}
+
+func registerGetAlertingReport(s *mcp.Server, source history.Source) {
+	input := mcputil.NewInput[getAlertingReportArgs](
+		output.PageSchema("fired, firing, median, p95, mttr, mtta, silenced, repeatRate, trend, or key (default: -fired)"),
+	)
+	s.AddTool(&mcp.Tool{
+		Name:        "getAlertingReport",
+		Description: "Alerting KPIs over a period, per alertname, namespace or any label: how often alerts fired, median and p95 firing duration, MTTR, time to silence as a proxy for MTTA, repeat rate and week-over-week trend, plus weekly volume. Requires alert history (history file or Prometheus).",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Analytics: Get Alerting Report",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[alertingReport](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		if source == nil {
+			return mcputil.NewErrorResult(noHistory), nil
+		}
+
+		now := time.Now()
+		window, since, err := parseWindow(args.Window, "30d", now)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		groupBy := args.GroupBy
+		if groupBy == "" {
+			groupBy = "alertname"
+		}
+		if !output.LabelVisible(groupBy, nil) {
+			return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"groupBy\": label %q is hidden by the server's label filter", groupBy)), nil
+		}
+		page := args.Page()
+		if page.SortBy == "" {
+			page.SortBy = "-fired"
+		}
+
+		// The week-over-week trend needs the last two weeks even for shorter windows.
+		from := since
+		if twoWeeks := now.Add(-2 * week); twoWeeks.Before(from) {
+			from = twoWeeks
+		}
+		occurrences, err := source.Occurrences(ctx, "", from)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alert history: %v", err)), nil
+		}
+
+		groups := make(map[string][]history.Occurrence)
+		for _, o := range occurrences {
+			groups[o.Labels[groupBy]] = append(groups[o.Labels[groupBy]], o)
+		}
+		rows := make([]reportRow, 0, len(groups))
+		for key, group := range groups {
+			if row := newReportRow(key, group, since, now); row.Fired > 0 || row.ThisWeek > 0 || row.LastWeek > 0 {
+				rows = append(rows, row)
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+		err = output.Sort(rows, page.SortBy, func(key string) func(a, b reportRow) int {
+			switch key {
+			case "fired":
+				return func(a, b reportRow) int { return cmp.Compare(a.Fired, b.Fired) }
+			case "firing":
+				return func(a, b reportRow) int { return cmp.Compare(a.FiringSeconds, b.FiringSeconds) }
+			case "median":
+				return func(a, b reportRow) int { return cmp.Compare(a.MedianSeconds, b.MedianSeconds) }
+			case "p95":
+				return func(a, b reportRow) int { return cmp.Compare(a.P95Seconds, b.P95Seconds) }
+			case "mttr":
+				return func(a, b reportRow) int { return cmp.Compare(a.MTTRSeconds, b.MTTRSeconds) }
+			case "mtta":
+				return func(a, b reportRow) int { return cmp.Compare(a.MTTASeconds, b.MTTASeconds) }
+			case "silenced":
+				return func(a, b reportRow) int { return cmp.Compare(a.Silenced, b.Silenced) }
+			case "repeatRate":
+				return func(a, b reportRow) int { return cmp.Compare(a.RepeatRate, b.RepeatRate) }
+			case "trend":
+				return func(a, b reportRow) int { return cmp.Compare(a.ThisWeek-a.LastWeek, b.ThisWeek-b.LastWeek) }
+			case "key":
+				return func(a, b reportRow) int { return cmp.Compare(a.Key, b.Key) }
+			default:
+				return nil
+			}
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		totals := newReportRow("total", occurrences, since, now)
+		weekly := weeklyVolume(occurrences, since, now)
+		notes := []string{
+			fmt.Sprintf("Last %s: %d firing(s) of %d label set(s), firing for %s in total; median %s, p95 %s.",
+				window, totals.Fired, totals.Instances, output.FormatSeconds(totals.FiringSeconds),
+				output.FormatSeconds(totals.MedianSeconds), output.FormatSeconds(totals.P95Seconds)),
+			fmt.Sprintf("Resolved: %d firing(s), after %s on average (MTTR).", totals.Resolved, output.FormatSeconds(totals.MTTRSeconds)),
+			fmt.Sprintf("Week over week: %d firing(s) in the last 7 days, %d in the 7 days before (%s).", totals.ThisWeek, totals.LastWeek, trend(totals)),
+		}
+		if totals.Silenced > 0 {
+			notes = append(notes, fmt.Sprintf("Silenced: %d firing(s), after %s on average (time to silence, a proxy for MTTA).",
+				totals.Silenced, output.FormatSeconds(totals.MTTASeconds)))
+		} else {
+			notes = append(notes, "No firing was silenced in the window, so there is no time to silence (MTTA). Silences are recorded by the history file, not by the Prometheus ALERTS series.")
+		}
+		notes = append(notes, "Source: "+source.Name())
+		if recorded := source.RecordedSince(); recorded.After(since) {
+			notes = append(notes, fmt.Sprintf("History is only recorded since %s, so the window is not fully covered.", output.FormatTime(recorded)))
+		}
+
+		var data alertingReport
+		result, err := output.Paginate(rows, page, "groups", args.Format, func(items []reportRow) (string, any, error) {
+			data = alertingReport{
+				Window:  window,
+				Since:   since,
+				Source:  source.Name(),
+				GroupBy: groupBy,
+				Totals:  totals,
+				Weekly:  weekly,
+				Total:   len(rows),
+				Offset:  page.Offset,
+				Groups:  items,
+			}
+			report := output.Report{Title: "Alerting Report", Notes: notes}
+			if len(rows) == 0 {
+				report.Notes = append(report.Notes, "No alerts fired in the window.")
+			} else {
+				report.Tables = []output.Table{reportTable(groupBy, items), weeklyTable(weekly)}
+			}
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format report: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// alertingReport is the structured result of getAlertingReport.
+type alertingReport struct {
+	Window  string       `json:"window"`
+	Since   time.Time    `json:"since" jsonschema:"start of the window"`
+	Source  string       `json:"source" jsonschema:"where the history comes from"`
+	GroupBy string       `json:"groupBy"`
+	Totals  reportRow    `json:"totals" jsonschema:"KPIs over all alerts"`
+	Weekly  []weekVolume `json:"weekly" jsonschema:"firings per week, most recent first"`
+	Total   int          `json:"total" jsonschema:"number of groups across all pages"`
+	Offset  int          `json:"offset" jsonschema:"index of the first returned group"`
+	Groups  []reportRow  `json:"groups"`
+}
+
+// reportRow holds the KPIs of the firings of one group.
+type reportRow struct {
+	Key           string  `json:"key" jsonschema:"value of the groupBy label; empty if unset"`
+	Fired         int     `json:"fired" jsonschema:"firings that started in the window"`
+	Instances     int     `json:"instances" jsonschema:"distinct label sets that fired"`
+	FiringSeconds int64   `json:"firingSeconds" jsonschema:"total firing time of these firings"`
+	MedianSeconds int64   `json:"medianSeconds" jsonschema:"median firing duration"`
+	P95Seconds    int64   `json:"p95Seconds" jsonschema:"95th percentile firing duration"`
+	Resolved      int     `json:"resolved" jsonschema:"firings that resolved"`
+	MTTRSeconds   int64   `json:"mttrSeconds" jsonschema:"mean time to resolve, over resolved firings"`
+	Silenced      int     `json:"silenced" jsonschema:"firings that were silenced"`
+	MTTASeconds   int64   `json:"mttaSeconds" jsonschema:"mean time from firing to silence, a proxy for time to acknowledge"`
+	RepeatRate    float64 `json:"repeatRate" jsonschema:"share of firings by a label set that already fired in the window, from 0 to 1"`
+	ThisWeek      int     `json:"thisWeek" jsonschema:"firings in the last 7 days"`
+	LastWeek      int     `json:"lastWeek" jsonschema:"firings in the 7 days before"`
+}
+
+// weekVolume is the number of firings that started in one week.
+type weekVolume struct {
+	Start         time.Time `json:"start"`
+	Fired         int       `json:"fired"`
+	FiringSeconds int64     `json:"firingSeconds"`
+}
+
+func newReportRow(key string, occurrences []history.Occurrence, since, now time.Time) reportRow {
+	row := reportRow{Key: key}
+	var durations, resolved, toSilence []time.Duration
+	var firing time.Duration
+	seen := make(map[string]bool)
+	for _, o := range occurrences {
+		age := now.Sub(o.StartsAt)
+		switch {
+		case age < week:
+			row.ThisWeek++
+		case age < 2*week:
+			row.LastWeek++
+		}
+		if o.StartsAt.Before(since) {
+			continue
+		}
+		row.Fired++
+		if seen[o.Fingerprint] {
+			row.RepeatRate++
+		}
+		seen[o.Fingerprint] = true
+		d := o.Duration(now)
+		durations = append(durations, d)
+		firing += d
+		if !o.Open() {
+			resolved = append(resolved, d)
+		}
+		for _, t := range o.Transitions {
+			if len(t.SilencedBy) > 0 {
+				toSilence = append(toSilence, t.Time.Sub(o.StartsAt))
+				break
+			}
+		}
+	}
+	row.Instances = len(seen)
+	row.FiringSeconds = int64(firing.Seconds())
+	row.MedianSeconds = int64(history.Percentile(durations, 50).Seconds())
+	row.P95Seconds = int64(history.Percentile(durations, 95).Seconds())
+	row.Resolved = len(resolved)
+	row.MTTRSeconds = int64(mean(resolved).Seconds())
+	row.Silenced = len(toSilence)
+	row.MTTASeconds = int64(mean(toSilence).Seconds())
+	if row.Fired > 0 {
+		row.RepeatRate /= float64(row.Fired)
+	}
+	return row
+}
+
+// weeklyVolume counts the firings that started in each week of the window,
+// counting weeks back from now.
+func weeklyVolume(occurrences []history.Occurrence, since, now time.Time) []weekVolume {
+	var weeks []weekVolume
+	for start := now.Add(-week); start.Add(week).After(since); start = start.Add(-week) {
+		weeks = append(weeks, weekVolume{Start: timeutil.Later(start, since)})
+	}
+	if len(weeks) == 0 {
+		return nil
+	}
+	for _, o := range occurrences {
+		if o.StartsAt.Before(since) {
+			continue
+		}
+		i := min(max(int(now.Sub(o.StartsAt)/week), 0), len(weeks)-1)
+		weeks[i].Fired++
+		weeks[i].FiringSeconds += int64(o.Duration(now).Seconds())
+	}
+	return weeks
+}
+
+func mean(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+// trend describes the week-over-week change, e.g. "+50%" or "new".
+func trend(r reportRow) string {
+	switch {
+	case r.LastWeek == 0 && r.ThisWeek == 0:
+		return "-"
+	case r.LastWeek == 0:
+		return "new"
+	default:
+		return fmt.Sprintf("%+d%%", (r.ThisWeek-r.LastWeek)*100/r.LastWeek)
+	}
+}
+
+func reportTable(groupBy string, rows []reportRow) output.Table {
+	t := output.Table{
+		Title:   "By " + groupBy,
+		Columns: []string{groupBy, "fired", "instances", "firing", "median", "p95", "mttr", "silenced", "mtta", "repeat", "thisWeek", "lastWeek", "trend"},
+	}
+	for _, r := range rows {
+		mttr, mtta := "-", "-"
+		if r.Resolved > 0 {
+			mttr = output.FormatSeconds(r.MTTRSeconds)
+		}
+		if r.Silenced > 0 {
+			mtta = output.FormatSeconds(r.MTTASeconds)
+		}
+		t.Rows = append(t.Rows, []string{
+			r.Key,
+			strconv.Itoa(r.Fired),
+			strconv.Itoa(r.Instances),
+			output.FormatSeconds(r.FiringSeconds),
+			output.FormatSeconds(r.MedianSeconds),
+			output.FormatSeconds(r.P95Seconds),
+			mttr,
+			strconv.Itoa(r.Silenced),
+			mtta,
+			fmt.Sprintf("%.0f%%", r.RepeatRate*100),
+			strconv.Itoa(r.ThisWeek),
+			strconv.Itoa(r.LastWeek),
+			trend(r),
+		})
+	}
+	return t
+}
+
+func weeklyTable(weeks []weekVolume) output.Table {
+	t := output.Table{Title: "Weekly Volume", Columns: []string{"weekStart", "fired", "firing"}}
+	for _, w := range weeks {
+		t.Rows = append(t.Rows, []string{
+			output.FormatTime(w.Start),
+			strconv.Itoa(w.Fired),
+			output.FormatSeconds(w.FiringSeconds),
+		})
+	}
+	return t
+}
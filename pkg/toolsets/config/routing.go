@@ -0,0 +1,245 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// Register registers all configuration-related tools.
+func Register(s *mcp.Server, client *alertmanager.Client) {
+	registerGetRoutingTree(s, client)
+	registerValidateConfig(s, client)
+}
+
+type getRoutingTreeArgs struct {
+	Format string `json:"format,omitempty" jsonschema:"Output format: 'text' (tree, default), 'mermaid', 'both', or 'table', 'markdown', 'json', 'csv'"`
+}
+
+func registerGetRoutingTree(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getRoutingTreeArgs](
+		mcputil.Enum("format", "text", "mermaid", "both", "table", "markdown", "json", "csv"),
+	)
+	s.AddTool(&mcp.Tool{
+		Name:        "getRoutingTree",
+		Description: "Show the notification routing tree: receivers, matchers, grouping and number of firing alerts per route. Output as indented text, Mermaid flowchart, both, or a flat route list as table, markdown, JSON or CSV.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Config: Get Routing Tree",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[routingTree](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		format := "text"
+		if args.Format != "" {
+			format = args.Format
+		}
+		diagram := format == "text" || format == "mermaid" || format == "both"
+
+		cfg, err := client.GetConfig()
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get configuration: %v", err)), nil
+		}
+		tree, err := cfg.RoutingTree()
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to resolve routing tree: %v", err)), nil
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "", "")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+		firing := countFiringPerRoute(tree, alerts)
+		data := flattenTree(tree, firing)
+
+		if !diagram {
+			result, err := output.Render(output.Format(format), data, output.Report{
+				Title:  "Routing Tree",
+				Tables: []output.Table{routesTable(data.Routes)},
+			})
+			if err != nil {
+				return mcputil.NewErrorResult(fmt.Sprintf("Failed to format routing tree: %v", err)), nil
+			}
+			return mcputil.NewStructuredResult(result, data), nil
+		}
+
+		var sb strings.Builder
+		if format == "text" || format == "both" {
+			sb.WriteString("=== Routing Tree ===\n")
+			writeTextTree(&sb, tree, firing, "", true)
+		}
+		if format == "both" {
+			sb.WriteString("\n")
+		}
+		if format == "mermaid" || format == "both" {
+			sb.WriteString("```mermaid\n")
+			writeMermaid(&sb, tree, firing)
+			sb.WriteString("```\n")
+		}
+		return mcputil.NewStructuredResult(sb.String(), data), nil
+	})
+}
+
+// routingTree is the structured form of the routing tree: every route in
+// depth-first order, linked to its parent by ID.
+type routingTree struct {
+	Routes []routeEntry `json:"routes"`
+}
+
+// routeEntry is a resolved route without its children.
+type routeEntry struct {
+	ID                  string                 `json:"id"`
+	Parent              string                 `json:"parent,omitempty"`
+	Depth               int                    `json:"depth"`
+	Receiver            string                 `json:"receiver"`
+	Matchers            []alertmanager.Matcher `json:"matchers,omitempty"`
+	GroupBy             []string               `json:"groupBy,omitempty"`
+	Continue            bool                   `json:"continue"`
+	GroupWait           string                 `json:"groupWait,omitempty"`
+	GroupInterval       string                 `json:"groupInterval,omitempty"`
+	RepeatInterval      string                 `json:"repeatInterval,omitempty"`
+	MuteTimeIntervals   []string               `json:"muteTimeIntervals,omitempty"`
+	ActiveTimeIntervals []string               `json:"activeTimeIntervals,omitempty"`
+	Firing              int                    `json:"firing" jsonschema:"number of firing alerts flowing through the route"`
+}
+
+func flattenTree(tree *alertmanager.RouteNode, firing map[string]int) routingTree {
+	var data routingTree
+	var walk func(n *alertmanager.RouteNode, parent string)
+	walk = func(n *alertmanager.RouteNode, parent string) {
+		data.Routes = append(data.Routes, routeEntry{
+			ID:                  n.ID,
+			Parent:              parent,
+			Depth:               n.Depth,
+			Receiver:            n.Receiver,
+			Matchers:            n.Matchers,
+			GroupBy:             n.GroupBy,
+			Continue:            n.Continue,
+			GroupWait:           n.GroupWait,
+			GroupInterval:       n.GroupInterval,
+			RepeatInterval:      n.RepeatInterval,
+			MuteTimeIntervals:   n.MuteTimeIntervals,
+			ActiveTimeIntervals: n.ActiveTimeIntervals,
+			Firing:              firing[n.ID],
+		})
+		for _, child := range n.Routes {
+			walk(child, n.ID)
+		}
+	}
+	walk(tree, "")
+	return data
+}
+
+// routesTable lists the flattened routes, one row per route.
+func routesTable(routes []routeEntry) output.Table {
+	t := output.Table{Columns: []string{"id", "depth", "matchers", "receiver", "group_by", "continue", "firing"}}
+	for _, r := range routes {
+		t.Rows = append(t.Rows, []string{
+			r.ID,
+			strconv.Itoa(r.Depth),
+			matchersLabel(r.Depth, r.Matchers),
+			r.Receiver,
+			strings.Join(r.GroupBy, ", "),
+			strconv.FormatBool(r.Continue),
+			strconv.Itoa(r.Firing),
+		})
+	}
+	return t
+}
+
+// countFiringPerRoute counts the firing alerts flowing through each route.
+func countFiringPerRoute(tree *alertmanager.RouteNode, alerts []alertmanager.GettableAlert) map[string]int {
+	counts := make(map[string]int)
+	for _, a := range alerts {
+		for _, node := range tree.Trace(a.Labels) {
+			counts[node.ID]++
+		}
+	}
+	return counts
+}
+
+func routeLabel(n *alertmanager.RouteNode) string {
+	return matchersLabel(n.Depth, n.Matchers)
+}
+
+func matchersLabel(depth int, matchers []alertmanager.Matcher) string {
+	if depth == 0 {
+		return "root"
+	}
+	if len(matchers) == 0 {
+		return "{} (catch-all)"
+	}
+	return alertmanager.FormatMatchers(matchers)
+}
+
+func routeDetails(n *alertmanager.RouteNode) []string {
+	details := []string{"receiver: " + n.Receiver}
+	if len(n.GroupBy) > 0 {
+		details = append(details, "group_by: ["+strings.Join(n.GroupBy, ", ")+"]")
+	}
+	if n.Continue {
+		details = append(details, "continue")
+	}
+	if len(n.MuteTimeIntervals) > 0 {
+		details = append(details, "muted: "+strings.Join(n.MuteTimeIntervals, ", "))
+	}
+	if len(n.ActiveTimeIntervals) > 0 {
+		details = append(details, "active: "+strings.Join(n.ActiveTimeIntervals, ", "))
+	}
+	return details
+}
+
+func writeTextTree(sb *strings.Builder, n *alertmanager.RouteNode, firing map[string]int, prefix string, last bool) {
+	connector, childPrefix := "", ""
+	if n.Depth > 0 {
+		connector, childPrefix = "├── ", prefix+"│   "
+		if last {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%s%s%s → %s (%d firing)\n",
+		prefix, connector, routeLabel(n), strings.Join(routeDetails(n), "  "), firing[n.ID]))
+	for i, child := range n.Routes {
+		writeTextTree(sb, child, firing, childPrefix, i == len(n.Routes)-1)
+	}
+}
+
+func writeMermaid(sb *strings.Builder, tree *alertmanager.RouteNode, firing map[string]int) {
+	sb.WriteString("flowchart LR\n")
+	tree.Walk(func(n *alertmanager.RouteNode) {
+		lines := append([]string{routeLabel(n)}, routeDetails(n)...)
+		lines = append(lines, fmt.Sprintf("%d firing", firing[n.ID]))
+		for i, l := range lines {
+			lines[i] = mermaidEscape(l)
+		}
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", mermaidID(n), strings.Join(lines, "<br/>")))
+		for _, child := range n.Routes {
+			arrow := "-->"
+			if child.Continue {
+				arrow = "-.->"
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s %s\n", mermaidID(n), arrow, mermaidID(child)))
+		}
+	})
+}
+
+func mermaidID(n *alertmanager.RouteNode) string {
+	return strings.ReplaceAll(n.ID, ".", "_")
+}
+
+func mermaidEscape(s string) string {
+	r := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	return r.Replace(s)
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+type validateConfigArgs struct {
+	Config string `json:"config" jsonschema:"Proposed alertmanager.yml content"`
+	output.FormatArgs
+}
+
+func registerValidateConfig(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[validateConfigArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "validateAlertmanagerConfig",
+		Description: "Validate a proposed alertmanager.yml: structural checks (unknown/duplicate receivers, invalid matchers, unreachable routes), semantic diff against the running config, and how currently firing alerts would be re-routed.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Config: Validate Alertmanager Config",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[validation](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		proposedYAML := args.Config
+		if strings.TrimSpace(proposedYAML) == "" {
+			return mcputil.NewErrorResult("config parameter is required"), nil
+		}
+
+		res := validation{}
+		report := output.Report{Title: "Config Validation"}
+		finish := func(note string) (*mcp.CallToolResult, error) {
+			if note != "" {
+				res.Notes = append(res.Notes, note)
+			}
+			report.Notes = append(report.Notes, res.Notes...)
+			result, err := output.Render(args.Format, res, report)
+			if err != nil {
+				return mcputil.NewErrorResult(fmt.Sprintf("Failed to format validation: %v", err)), nil
+			}
+			return mcputil.NewStructuredResult(result, res), nil
+		}
+
+		proposed, err := alertmanager.ParseConfig(proposedYAML)
+		if err != nil {
+			res.Result = "INVALID"
+			report.Notes = []string{"Result: INVALID"}
+			return finish(fmt.Sprintf("error: %v", err))
+		}
+
+		res.Issues = alertmanager.ValidateConfig(proposed)
+		for _, issue := range res.Issues {
+			if issue.Severity == "error" {
+				res.Errors++
+			} else {
+				res.Warnings++
+			}
+		}
+		if res.Errors > 0 {
+			res.Result = "INVALID"
+			report.Notes = []string{fmt.Sprintf("Result: INVALID (%d errors, %d warnings)", res.Errors, res.Warnings)}
+		} else {
+			res.Result = "VALID"
+			report.Notes = []string{fmt.Sprintf("Result: VALID (%d warnings)", res.Warnings)}
+		}
+
+		issues := output.Table{Title: "Issues", Columns: []string{"severity", "path", "message"}}
+		for _, issue := range res.Issues {
+			issues.Rows = append(issues.Rows, []string{issue.Severity, issue.Path, issue.Message})
+		}
+		report.Tables = append(report.Tables, issues)
+
+		current, err := client.GetConfig()
+		if err != nil {
+			return finish(fmt.Sprintf("Could not compare with running config: %v", err))
+		}
+
+		res.Changes = alertmanager.DiffConfigs(current, proposed)
+		changes := output.Table{Title: "Changes vs Running Config", Columns: []string{"action", "kind", "name", "detail"}}
+		for _, c := range res.Changes {
+			changes.Rows = append(changes.Rows, []string{c.Action, c.Kind, c.Name, c.Detail})
+		}
+		report.Tables = append(report.Tables, changes)
+
+		if res.Errors > 0 {
+			return finish("Fix the errors to see how firing alerts would be re-routed.")
+		}
+
+		currentTree, err := current.RoutingTree()
+		if err != nil {
+			return finish(fmt.Sprintf("Could not resolve running routing tree: %v", err))
+		}
+		proposedTree, err := proposed.RoutingTree()
+		if err != nil {
+			return finish(fmt.Sprintf("Could not resolve proposed routing tree: %v", err))
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "", "")
+		if err != nil {
+			return finish(fmt.Sprintf("Could not get firing alerts: %v", err))
+		}
+
+		res.Firing = len(alerts)
+		rerouted := output.Table{
+			Title:   fmt.Sprintf("Re-routed Firing Alerts (of %d)", len(alerts)),
+			Columns: []string{"alertname", "namespace", "before", "after"},
+		}
+		for _, a := range alerts {
+			before := routeReceivers(currentTree.Match(a.Labels))
+			after := routeReceivers(proposedTree.Match(a.Labels))
+			if before == after {
+				continue
+			}
+			r := reroute{
+				AlertName: a.Labels["alertname"],
+				Namespace: output.Labels(a.Labels, nil)["namespace"],
+				Before:    before,
+				After:     after,
+			}
+			res.Rerouted = append(res.Rerouted, r)
+			rerouted.Rows = append(rerouted.Rows, []string{r.AlertName, r.Namespace, r.Before, r.After})
+		}
+		report.Tables = append(report.Tables, rerouted)
+
+		return finish("")
+	})
+}
+
+// validation is the structured result of a config validation.
+type validation struct {
+	Result   string                      `json:"result"`
+	Errors   int                         `json:"errors"`
+	Warnings int                         `json:"warnings"`
+	Issues   []alertmanager.ConfigIssue  `json:"issues"`
+	Changes  []alertmanager.ConfigChange `json:"changes"`
+	Firing   int                         `json:"firing"`
+	Rerouted []reroute                   `json:"rerouted"`
+	Notes    []string                    `json:"notes,omitempty"`
+}
+
+// reroute is a firing alert whose receivers change under the proposed config.
+type reroute struct {
+	AlertName string `json:"alertname"`
+	Namespace string `json:"namespace,omitempty"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+func routeReceivers(routes []*alertmanager.RouteNode) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, r := range routes {
+		if !seen[r.Receiver] {
+			seen[r.Receiver] = true
+			names = append(names, r.Receiver)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
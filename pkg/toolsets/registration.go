@@ -4,16 +4,31 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/kubernetes"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/alerts"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/analytics"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/config"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/silences"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/status"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/toolsets/troubleshooting"
 )
 
+// Options are the optional features tools can use.
+type Options struct {
+	// History is the alert history, from the history file or Prometheus; nil
+	// if history is disabled.
+	History history.Source
+	// Cluster looks up Kubernetes objects; nil if no cluster is configured.
+	Cluster *kubernetes.Cluster
+}
+
 // RegisterAll registers all Alertmanager MCP tools with the server.
-func RegisterAll(s *mcp.Server, client *alertmanager.Client) {
+func RegisterAll(s *mcp.Server, client *alertmanager.Client, opts Options) {
 	alerts.Register(s, client)
-	silences.Register(s, client)
+	silences.Register(s, client, opts.Cluster)
 	status.Register(s, client)
-	troubleshooting.Register(s, client)
+	config.Register(s, client)
+	troubleshooting.Register(s, client, opts.History)
+	analytics.Register(s, client, opts.History)
 }
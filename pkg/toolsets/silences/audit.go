@@ -0,0 +1,330 @@
+package silences
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
+)
+
+const (
+	// recreatedThreshold is how many silences with the same matchers make
+	// them repeatedly re-created.
+	recreatedThreshold = 3
+	// broadShare is the share of alerts from which a silence is too broad.
+	broadShare = 0.5
+	// broadMinAlerts keeps small installations from flagging every silence.
+	broadMinAlerts = 5
+
+	defaultExpiringWithin = "24h"
+	defaultLongRunning    = "7d"
+)
+
+// Silence audit issues.
+const (
+	issueExpiringSoon = "expiring-soon"
+	issueBroad        = "too-broad"
+	issueMatchesNone  = "matches-nothing"
+	issueDuplicate    = "duplicate"
+	issueLongRunning  = "long-running"
+	issueRecreated    = "re-created"
+)
+
+type auditSilencesArgs struct {
+	ExpiringWithin string `json:"expiringWithin,omitempty" jsonschema:"Report silences ending within this time that still cover firing alerts: '2h', '24h', '3d' (default: 24h)"`
+	LongRunning    string `json:"longRunning,omitempty" jsonschema:"Report silences lasting longer than this: '3d', '7d', '30d' (default: 7d)"`
+	output.PageArgs
+	output.FormatArgs
+}
+
+func registerAuditSilences(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[auditSilencesArgs](output.PageSchema("priority, endsAt, issue, createdBy, or id (default: priority)"))
+	s.AddTool(&mcp.Tool{
+		Name:        "auditSilences",
+		Description: "Audit silence hygiene against current alerts: silences expiring soon that still cover firing alerts, silences matching no alerts, overly broad (regex or negative) silences, duplicates with identical matchers, very long-running and repeatedly re-created silences. Returns a prioritized list with recommendations.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Silences: Audit Silences",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[silenceAudit](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		expiringWithin, err := durationArg("expiringWithin", args.ExpiringWithin, defaultExpiringWithin)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		longRunning, err := durationArg("longRunning", args.LongRunning, defaultLongRunning)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		page := args.Page()
+		if page.SortBy == "" {
+			page.SortBy = "priority"
+		}
+
+		silences, err := client.GetSilences("")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get silences: %v", err)), nil
+		}
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+
+		findings, live := auditSilences(silences, alerts, expiringWithin, longRunning, time.Now())
+		err = output.Sort(findings, page.SortBy, func(key string) func(a, b silenceFinding) int {
+			switch key {
+			case "priority":
+				return func(a, b silenceFinding) int {
+					return cmp.Compare(output.PriorityRank(a.Priority), output.PriorityRank(b.Priority))
+				}
+			case "endsAt":
+				return func(a, b silenceFinding) int { return a.EndsAt.Compare(b.EndsAt) }
+			case "issue":
+				return func(a, b silenceFinding) int { return cmp.Compare(a.Issue, b.Issue) }
+			case "createdBy":
+				return func(a, b silenceFinding) int { return cmp.Compare(a.CreatedBy, b.CreatedBy) }
+			case "id":
+				return func(a, b silenceFinding) int { return cmp.Compare(a.SilenceID, b.SilenceID) }
+			default:
+				return nil
+			}
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		counts := make(map[string]int)
+		for _, f := range findings {
+			counts[f.Issue]++
+		}
+		notes := []string{fmt.Sprintf("Audited %d active or pending silence(s) against %d alert(s): %d finding(s).", live, len(alerts), len(findings))}
+		for _, issue := range []string{issueExpiringSoon, issueBroad, issueMatchesNone, issueDuplicate, issueLongRunning, issueRecreated} {
+			if counts[issue] > 0 {
+				notes = append(notes, fmt.Sprintf("  %s: %d", issue, counts[issue]))
+			}
+		}
+
+		var data silenceAudit
+		result, err := output.Paginate(findings, page, "findings", args.Format, func(items []silenceFinding) (string, any, error) {
+			data = silenceAudit{
+				Silences: live,
+				Alerts:   len(alerts),
+				Total:    len(findings),
+				Offset:   page.Offset,
+				Findings: items,
+			}
+			report := output.Report{Title: "Silence Audit", Notes: notes}
+			if len(findings) == 0 {
+				report.Notes = append(report.Notes, "No problems found.")
+			} else {
+				report.Tables = []output.Table{auditTable(items)}
+			}
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format audit: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// silenceAudit is the structured result of auditSilences.
+type silenceAudit struct {
+	Silences int              `json:"silences" jsonschema:"number of active and pending silences audited"`
+	Alerts   int              `json:"alerts" jsonschema:"number of current alerts matched against"`
+	Total    int              `json:"total" jsonschema:"number of findings across all pages"`
+	Offset   int              `json:"offset" jsonschema:"index of the first returned finding"`
+	Findings []silenceFinding `json:"findings"`
+}
+
+// silenceFinding is a hygiene problem of a silence.
+type silenceFinding struct {
+	Priority       string    `json:"priority" jsonschema:"high, medium or low"`
+	Issue          string    `json:"issue" jsonschema:"expiring-soon, too-broad, matches-nothing, duplicate, long-running or re-created"`
+	SilenceID      string    `json:"silenceId"`
+	State          string    `json:"state"`
+	Matchers       string    `json:"matchers"`
+	CreatedBy      string    `json:"createdBy"`
+	Comment        string    `json:"comment"`
+	EndsAt         time.Time `json:"endsAt"`
+	Alerts         int       `json:"alerts" jsonschema:"number of current alerts the silence matches"`
+	Detail         string    `json:"detail"`
+	Recommendation string    `json:"recommendation"`
+}
+
+// auditSilences checks the active and pending silences against the alerts
+// and the silence history, and returns the findings with the number of
+// silences audited.
+func auditSilences(silences []alertmanager.GettableSilence, alerts []alertmanager.GettableAlert, expiringWithin, longRunning time.Duration, now time.Time) ([]silenceFinding, int) {
+	// Silences with the same matchers, in any state, by matcher set.
+	byMatchers := make(map[string][]alertmanager.GettableSilence)
+	var live []alertmanager.GettableSilence
+	for _, s := range silences {
+		key := matcherKey(s.Matchers)
+		byMatchers[key] = append(byMatchers[key], s)
+		if s.Status.State != "expired" {
+			live = append(live, s)
+		}
+	}
+	output.SortSilences(live, "endsAt")
+
+	var findings []silenceFinding
+	for _, s := range live {
+		matched := 0
+		for _, a := range alerts {
+			if alertmanager.MatchAll(s.Matchers, a.Labels) {
+				matched++
+			}
+		}
+		add := func(priority, issue, detail, recommendation string) {
+			findings = append(findings, silenceFinding{
+				Priority:       priority,
+				Issue:          issue,
+				SilenceID:      s.ID,
+				State:          s.Status.State,
+				Matchers:       alertmanager.FormatMatchers(s.Matchers),
+				CreatedBy:      s.CreatedBy,
+				Comment:        s.Comment,
+				EndsAt:         s.EndsAt,
+				Alerts:         matched,
+				Detail:         detail,
+				Recommendation: recommendation,
+			})
+		}
+		active := s.Status.State == "active"
+
+		if left := s.EndsAt.Sub(now); active && matched > 0 && left <= expiringWithin {
+			add("high", issueExpiringSoon,
+				fmt.Sprintf("ends in %s and still covers %d firing alert(s)", output.FormatDuration(left), matched),
+				"Extend the silence if the work is still ongoing, or get ready for these alerts to notify.")
+		}
+		if reason := broadness(s.Matchers, matched, len(alerts)); reason != "" {
+			priority := "medium"
+			if matched >= broadMinAlerts {
+				priority = "high"
+			}
+			add(priority, issueBroad, reason,
+				"Narrow the matchers to the affected alertname, namespace or instance so unrelated alerts are not hidden.")
+		}
+		if active && matched == 0 {
+			add("medium", issueMatchesNone, "matches no current alert",
+				"Expire it if the problem it covered is gone.")
+		}
+		if dups := duplicates(s, byMatchers[matcherKey(s.Matchers)]); len(dups) > 0 {
+			add("medium", issueDuplicate, "same matchers as "+strings.Join(dups, ", "),
+				"Keep the silence ending last and expire the others.")
+		}
+		if d := s.EndsAt.Sub(s.StartsAt); d >= longRunning {
+			add("low", issueLongRunning, fmt.Sprintf("lasts %s in total", output.FormatDuration(d)),
+				"Fix or delete the silenced rule instead of silencing it for a long time, or shorten the silence.")
+		}
+		if n := len(byMatchers[matcherKey(s.Matchers)]); n >= recreatedThreshold {
+			add("low", issueRecreated, fmt.Sprintf("%d silences with the same matchers, including expired ones", n),
+				"Fix the recurring cause, or use a mute time interval in the route if the silence follows a schedule.")
+		}
+	}
+	return findings, len(live)
+}
+
+// broadness explains why the matchers are too broad, or returns "" if not.
+func broadness(matchers []alertmanager.Matcher, matched, total int) string {
+	var wildcards []string
+	positive := false
+	for _, m := range matchers {
+		if m.IsEqual && !matchesAnything(m) {
+			positive = true
+		}
+		if m.IsRegex && m.IsEqual && matchesAnything(m) {
+			wildcards = append(wildcards, m.String())
+		}
+	}
+	switch {
+	case len(wildcards) > 0:
+		return "regex matches any value: " + strings.Join(wildcards, ", ")
+	case !positive:
+		return "only negative matchers, so it matches everything else"
+	case matched >= broadMinAlerts && float64(matched) >= broadShare*float64(total):
+		return fmt.Sprintf("matches %d of %d current alerts", matched, total)
+	default:
+		return ""
+	}
+}
+
+// matchesAnything reports whether a matcher matches both a missing label and
+// an arbitrary value, like =~".*".
+func matchesAnything(m alertmanager.Matcher) bool {
+	return m.Matches(map[string]string{}) && m.Matches(map[string]string{m.Name: "audit-probe-Zx9"})
+}
+
+// duplicates returns the IDs of the other live silences with the same
+// matchers whose time range overlaps s.
+func duplicates(s alertmanager.GettableSilence, same []alertmanager.GettableSilence) []string {
+	var ids []string
+	for _, o := range same {
+		if o.ID == s.ID || o.Status.State == "expired" {
+			continue
+		}
+		if o.StartsAt.Before(s.EndsAt) && s.StartsAt.Before(o.EndsAt) {
+			ids = append(ids, o.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// matcherKey identifies a set of matchers regardless of their order.
+func matcherKey(matchers []alertmanager.Matcher) string {
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = m.String()
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ",")
+}
+
+// durationArg parses a duration argument, applying the default.
+func durationArg(name, value, defaultValue string) (time.Duration, error) {
+	if value == "" {
+		value = defaultValue
+	}
+	d, err := timeutil.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid argument %q: %v", name, err)
+	}
+	return d, nil
+}
+
+func auditTable(findings []silenceFinding) output.Table {
+	t := output.Table{
+		Title:   "Findings",
+		Columns: []string{"priority", "issue", "id", "matchers", "endsAt", "createdBy", "detail", "recommendation"},
+	}
+	for _, f := range findings {
+		t.Rows = append(t.Rows, []string{
+			f.Priority,
+			f.Issue,
+			f.SilenceID,
+			f.Matchers,
+			output.FormatTime(f.EndsAt),
+			f.CreatedBy,
+			f.Detail,
+			f.Recommendation,
+		})
+	}
+	return t
+}
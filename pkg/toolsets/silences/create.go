@@ -2,126 +2,165 @@ package silences
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"k8s.io/utils/ptr"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
 )
 
+// silenceArgs are the arguments shared by the tools that create silences.
+type silenceArgs struct {
+	StartsAt  string `json:"startsAt,omitempty" jsonschema:"When the silence starts: an RFC3339 timestamp, or a day and time with an optional time zone like 'Saturday 02:00 Europe/Madrid' or 'tomorrow 06:00', optionally followed by 'for' and the duration or 'until' and the end (default: now)"`
+	Duration  string `json:"duration,omitempty" jsonschema:"How long the silence lasts: a duration like '30m', '1h30m', '2d' or '1w', or an end like 'until 18:00', 'until Friday 09:00 Europe/Madrid' or an RFC3339 timestamp (default: 2h)"`
+	TimeZone  string `json:"timeZone,omitempty" jsonschema:"IANA time zone of times given without one, e.g. 'Europe/Madrid' (default: the server's time zone)"`
+	Comment   string `json:"comment,omitempty" jsonschema:"Reason for silence (default: 'Silenced via MCP')"`
+	CreatedBy string `json:"createdBy,omitempty" jsonschema:"Creator name (default: 'mcp-alertmanager')"`
+}
+
+// newSilence returns a silence with the given matchers, applying the
+// defaults: it starts now unless startsAt is given. Silences last at most 30
+// days.
+func (a silenceArgs) newSilence(matchers []alertmanager.Matcher, now time.Time) (alertmanager.PostableSilence, error) {
+	duration := "2h"
+	if a.Duration != "" {
+		duration = a.Duration
+	}
+
+	comment := "Silenced via MCP"
+	if a.Comment != "" {
+		comment = a.Comment
+	}
+
+	createdBy := "mcp-alertmanager"
+	if a.CreatedBy != "" {
+		createdBy = a.CreatedBy
+	}
+
+	loc := timeutil.Location()
+	if a.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(a.TimeZone); err != nil {
+			return alertmanager.PostableSilence{}, fmt.Errorf("invalid argument \"timeZone\": unknown time zone %q", a.TimeZone)
+		}
+	}
+
+	startsAt := now
+	var window time.Duration
+	if a.StartsAt != "" {
+		start, length, err := timeutil.ParseWindow(a.StartsAt, now, loc)
+		if err != nil {
+			return alertmanager.PostableSilence{}, fmt.Errorf("invalid argument \"startsAt\": %v", err)
+		}
+		if start.Before(now) {
+			return alertmanager.PostableSilence{}, fmt.Errorf("startsAt %s is in the past", output.FormatTime(start))
+		}
+		if length > 0 && a.Duration != "" {
+			return alertmanager.PostableSilence{}, errors.New("give the duration either in startsAt ('for 3h') or in duration, not both")
+		}
+		startsAt, window = start, length
+	}
+
+	dur := window
+	if dur == 0 {
+		var err error
+		if dur, err = timeutil.ParseLength(duration, startsAt, loc); err != nil {
+			return alertmanager.PostableSilence{}, fmt.Errorf("invalid argument \"duration\": %v", err)
+		}
+	}
+	if dur <= 0 {
+		return alertmanager.PostableSilence{}, errors.New("duration must be positive")
+	}
+
+	// Max 30 days
+	if dur > 30*24*time.Hour {
+		return alertmanager.PostableSilence{}, errors.New("duration cannot exceed 30 days")
+	}
+
+	return alertmanager.PostableSilence{
+		Comment:   comment,
+		CreatedBy: createdBy,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(dur),
+		Matchers:  matchers,
+	}, nil
+}
+
+// silenceTable returns the table view of created silences.
+func silenceTable(silences ...alertmanager.PostableSilence) output.Table {
+	t := output.Table{Columns: []string{"id", "matchers", "startsAt", "endsAt", "createdBy", "comment"}}
+	for _, silence := range silences {
+		t.Rows = append(t.Rows, []string{
+			silence.ID,
+			alertmanager.FormatMatchers(silence.Matchers),
+			output.FormatTime(silence.StartsAt),
+			output.FormatTime(silence.EndsAt),
+			silence.CreatedBy,
+			silence.Comment,
+		})
+	}
+	return t
+}
+
+type createSilenceArgs struct {
+	AlertName string `json:"alertName" jsonschema:"Alert name to silence"`
+	silenceArgs
+	output.FormatArgs
+}
+
 func registerCreateSilence(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[createSilenceArgs]()
 	s.AddTool(&mcp.Tool{
 		Name:        "createSilence",
-		Description: "Create a silence for an alert. Duration format: '30m', '2h', '1d'. Max 30 days.",
+		Description: "Create a silence for an alert, starting now or at a later startsAt such as 'Saturday 02:00 Europe/Madrid for 3h'. Duration format: '30m', '1h30m', '1w' or 'until 18:00'. Max 30 days.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:           "Silences: Create Silence",
 			ReadOnlyHint:    false,
 			DestructiveHint: ptr.To(false),
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"alertName": {
-					Type:        "string",
-					Description: "Alert name to silence",
-				},
-				"duration": {
-					Type:        "string",
-					Description: "Duration: '30m', '2h', '1d' (default: 2h)",
-				},
-				"comment": {
-					Type:        "string",
-					Description: "Reason for silence (default: 'Silenced via MCP')",
-				},
-				"createdBy": {
-					Type:        "string",
-					Description: "Creator name (default: 'mcp-alertmanager')",
-				},
-			},
-			Required: []string{"alertName"},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[alertmanager.PostableSilence](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
 
-		alertName, _ := args["alertName"].(string)
+		alertName := args.AlertName
 		if alertName == "" {
 			return mcputil.NewErrorResult("alertName parameter is required"), nil
 		}
 
-		duration := "2h"
-		if d, ok := args["duration"].(string); ok && d != "" {
-			duration = d
-		}
-
-		comment := "Silenced via MCP"
-		if c, ok := args["comment"].(string); ok && c != "" {
-			comment = c
-		}
-
-		createdBy := "mcp-alertmanager"
-		if cb, ok := args["createdBy"].(string); ok && cb != "" {
-			createdBy = cb
-		}
-
-		dur, err := parseDuration(duration)
-		if err != nil {
-			return mcputil.NewErrorResult(fmt.Sprintf("Invalid duration: %v", err)), nil
-		}
-
-		// Max 30 days
-		if dur > 30*24*time.Hour {
-			return mcputil.NewErrorResult("Duration cannot exceed 30 days"), nil
-		}
-
-		now := time.Now()
-		silence := alertmanager.PostableSilence{
-			Comment:   comment,
-			CreatedBy: createdBy,
-			StartsAt:  now,
-			EndsAt:    now.Add(dur),
-			Matchers: []alertmanager.Matcher{
-				{
-					IsEqual: true,
-					IsRegex: false,
-					Name:    "alertname",
-					Value:   alertName,
-				},
+		silence, err := args.newSilence([]alertmanager.Matcher{
+			{
+				IsEqual: true,
+				IsRegex: false,
+				Name:    "alertname",
+				Value:   alertName,
 			},
+		}, time.Now())
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
 		}
 
-		result, err := client.CreateSilence(silence)
+		silenceID, err := client.CreateSilence(silence)
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to create silence: %v", err)), nil
 		}
-		return mcputil.NewTextResult(fmt.Sprintf("Silence created successfully:\n%s", result)), nil
+		silence.ID = silenceID
+		result, err := output.Render(args.Format, silence, output.Report{
+			Title:  "Silence created successfully",
+			Tables: []output.Table{silenceTable(silence)},
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format silence: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, silence), nil
 	})
 }
-
-func parseDuration(s string) (time.Duration, error) {
-	if len(s) < 2 {
-		return 0, fmt.Errorf("invalid duration: %s", s)
-	}
-	unit := s[len(s)-1]
-	valStr := s[:len(s)-1]
-	var val int
-	if _, err := fmt.Sscanf(valStr, "%d", &val); err != nil {
-		return 0, fmt.Errorf("invalid duration value: %s", s)
-	}
-	switch unit {
-	case 'm':
-		return time.Duration(val) * time.Minute, nil
-	case 'h':
-		return time.Duration(val) * time.Hour, nil
-	case 'd':
-		return time.Duration(val) * 24 * time.Hour, nil
-	default:
-		return 0, fmt.Errorf("unknown duration unit: %c", unit)
-	}
-}
@@ -4,15 +4,21 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"k8s.io/utils/ptr"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+type deleteSilenceArgs struct {
+	SilenceID string `json:"silenceId" jsonschema:"Silence UUID"`
+	output.FormatArgs
+}
+
 func registerDeleteSilence(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[deleteSilenceArgs]()
 	s.AddTool(&mcp.Tool{
 		Name:        "deleteSilence",
 		Description: "Delete a silence by ID. Get ID from getSilences output.",
@@ -21,23 +27,14 @@ func registerDeleteSilence(s *mcp.Server, client *alertmanager.Client) {
 			ReadOnlyHint:    false,
 			DestructiveHint: ptr.To(true),
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"silenceId": {
-					Type:        "string",
-					Description: "Silence UUID",
-				},
-			},
-			Required: []string{"silenceId"},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[deletedSilence](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
-
-		silenceID, _ := args["silenceId"].(string)
+		silenceID := args.SilenceID
 		if silenceID == "" {
 			return mcputil.NewErrorResult("silenceId parameter is required"), nil
 		}
@@ -45,6 +42,19 @@ func registerDeleteSilence(s *mcp.Server, client *alertmanager.Client) {
 		if err := client.DeleteSilence(silenceID); err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to delete silence: %v", err)), nil
 		}
-		return mcputil.NewTextResult(fmt.Sprintf("Silence %s deleted successfully", silenceID)), nil
+		deleted := deletedSilence{SilenceID: silenceID, Deleted: true}
+		result, err := output.Render(args.Format, deleted, output.Report{
+			Notes: []string{fmt.Sprintf("Silence %s deleted successfully", silenceID)},
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, deleted), nil
 	})
 }
+
+// deletedSilence is the structured result of deleteSilence.
+type deletedSilence struct {
+	SilenceID string `json:"silenceID"`
+	Deleted   bool   `json:"deleted"`
+}
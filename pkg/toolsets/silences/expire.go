@@ -0,0 +1,159 @@
+package silences
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+type expireSilencesArgs struct {
+	CreatedBy string `json:"createdBy,omitempty" jsonschema:"Only silences created by this creator (exact match)"`
+	Comment   string `json:"comment,omitempty" jsonschema:"Only silences whose comment contains this text (case-insensitive)"`
+	Matchers  string `json:"matchers,omitempty" jsonschema:"Only silences that include all of these matchers, e.g. '{namespace=\"prod\"}' or 'alertname=~\"Kube.*\"'"`
+	State     string `json:"state,omitempty" jsonschema:"Only silences in this state (default: active and pending)"`
+	DryRun    *bool  `json:"dryRun,omitempty" jsonschema:"Only list the selected silences without expiring them (default: true)"`
+	output.FormatArgs
+}
+
+func registerExpireSilences(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[expireSilencesArgs](mcputil.Enum("state", "active", "pending"))
+	s.AddTool(&mcp.Tool{
+		Name:        "expireSilences",
+		Description: "Expire silences in bulk, selected by creator, comment text, matchers and state (all given criteria must match; at least one of createdBy, comment or matchers is required). Dry run by default: first list the selection, then call again with dryRun=false. Reports which silences were expired and which failed.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Silences: Expire Silences",
+			ReadOnlyHint:    false,
+			DestructiveHint: ptr.To(true),
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[expiredSilences](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		if args.CreatedBy == "" && args.Comment == "" && args.Matchers == "" {
+			return mcputil.NewErrorResult("at least one of createdBy, comment or matchers is required"), nil
+		}
+		var matchers []alertmanager.Matcher
+		if args.Matchers != "" {
+			if matchers, err = alertmanager.ParseMatchers(args.Matchers); err != nil {
+				return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"matchers\": %v", err)), nil
+			}
+		}
+		dryRun := args.DryRun == nil || *args.DryRun
+
+		silences, err := client.GetSilences(args.State)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get silences: %v", err)), nil
+		}
+		var selected []alertmanager.GettableSilence
+		for _, s := range silences {
+			if s.Status.State != "expired" && selectSilence(s, args.CreatedBy, args.Comment, matchers) {
+				selected = append(selected, s)
+			}
+		}
+		output.SortSilences(selected, "endsAt")
+
+		data := expiredSilences{DryRun: dryRun, Selected: len(selected), Silences: make([]expiredSilence, len(selected))}
+		for i, s := range selected {
+			data.Silences[i] = expiredSilence{
+				ID:        s.ID,
+				State:     s.Status.State,
+				Matchers:  alertmanager.FormatMatchers(s.Matchers),
+				CreatedBy: s.CreatedBy,
+				Comment:   s.Comment,
+				EndsAt:    s.EndsAt,
+			}
+			if dryRun {
+				continue
+			}
+			if err := client.DeleteSilence(s.ID); err != nil {
+				data.Silences[i].Error = err.Error()
+				data.Failed++
+				continue
+			}
+			data.Silences[i].Expired = true
+			data.Expired++
+		}
+
+		report := output.Report{Title: "Expire Silences"}
+		switch {
+		case len(selected) == 0:
+			report.Notes = []string{"No silences match the selection."}
+		case dryRun:
+			report.Notes = []string{fmt.Sprintf("Dry run: %d silence(s) would be expired. Call again with dryRun=false to expire them.", len(selected))}
+		default:
+			report.Notes = []string{fmt.Sprintf("Expired %d of %d silence(s), %d failed.", data.Expired, len(selected), data.Failed)}
+		}
+		if len(selected) > 0 {
+			report.Tables = []output.Table{expiredTable(data)}
+		}
+		result, err := output.Render(args.Format, data, report)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// expiredSilences is the structured result of expireSilences.
+type expiredSilences struct {
+	DryRun   bool             `json:"dryRun"`
+	Selected int              `json:"selected" jsonschema:"number of silences matching the selection"`
+	Expired  int              `json:"expired"`
+	Failed   int              `json:"failed"`
+	Silences []expiredSilence `json:"silences"`
+}
+
+// expiredSilence is a selected silence and the outcome of expiring it.
+type expiredSilence struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state" jsonschema:"state before expiring"`
+	Matchers  string    `json:"matchers"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+	EndsAt    time.Time `json:"endsAt"`
+	Expired   bool      `json:"expired"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// selectSilence reports whether a silence matches all given criteria.
+func selectSilence(s alertmanager.GettableSilence, createdBy, comment string, matchers []alertmanager.Matcher) bool {
+	if createdBy != "" && s.CreatedBy != createdBy {
+		return false
+	}
+	if comment != "" && !strings.Contains(strings.ToLower(s.Comment), strings.ToLower(comment)) {
+		return false
+	}
+	for _, m := range matchers {
+		if !slices.Contains(s.Matchers, m) {
+			return false
+		}
+	}
+	return true
+}
+
+func expiredTable(data expiredSilences) output.Table {
+	t := output.Table{Columns: []string{"id", "state", "matchers", "endsAt", "createdBy", "comment", "result"}}
+	for _, s := range data.Silences {
+		result := "would expire"
+		switch {
+		case s.Error != "":
+			result = "failed: " + s.Error
+		case s.Expired:
+			result = "expired"
+		}
+		t.Rows = append(t.Rows, []string{s.ID, s.State, s.Matchers, output.FormatTime(s.EndsAt), s.CreatedBy, s.Comment, result})
+	}
+	return t
+}
@@ -4,21 +4,40 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/kubernetes"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
-// Register registers all silence-related tools.
-func Register(s *mcp.Server, client *alertmanager.Client) {
+// Register registers all silence-related tools. cluster resolves Kubernetes
+// nodes for maintenance; nil if no cluster is configured.
+func Register(s *mcp.Server, client *alertmanager.Client, cluster *kubernetes.Cluster) {
 	registerGetSilences(s, client)
 	registerCreateSilence(s, client)
+	registerSilenceAlertGroup(s, client)
+	registerSilenceAlertInstance(s, client)
+	registerSuggestSilenceMatchers(s, client)
 	registerDeleteSilence(s, client)
+	registerExpireSilences(s, client)
+	registerAuditSilences(s, client)
+	registerStartMaintenance(s, client, cluster)
+	registerEndMaintenance(s, client)
+}
+
+type getSilencesArgs struct {
+	State string `json:"state,omitempty" jsonschema:"Only return silences in this state (default: all)"`
+	output.PageArgs
+	output.FormatArgs
 }
 
 func registerGetSilences(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getSilencesArgs](
+		mcputil.Enum("state", "active", "pending", "expired"),
+		output.PageSchema("startsAt, endsAt, updatedAt, createdBy, state, id"),
+	)
 	s.AddTool(&mcp.Tool{
 		Name:        "getSilences",
 		Description: "List silences. Filter by state: 'active', 'pending', 'expired', or omit for all.",
@@ -26,27 +45,32 @@ func registerGetSilences(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Silences: Get Silences",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"state": {
-					Type:        "string",
-					Description: "State: 'active', 'pending', 'expired'",
-				},
-			},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[output.List[alertmanager.GettableSilence]](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
+		page := args.Page()
 
-		state, _ := args["state"].(string)
-
-		result, err := client.GetSilences(state)
+		silences, err := client.GetSilences(args.State)
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get silences: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+		if err := output.SortSilences(silences, page.SortBy); err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		var list output.List[alertmanager.GettableSilence]
+		result, err := output.Paginate(silences, page, "silences", args.Format, func(items []alertmanager.GettableSilence) (string, any, error) {
+			list = output.List[alertmanager.GettableSilence]{Items: items, Total: len(silences), Offset: page.Offset}
+			return output.RenderPage(args.Format, list, output.Report{
+				Tables: []output.Table{output.SilencesTable("", items)},
+			})
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format silences: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, list), nil
 	})
 }
@@ -0,0 +1,278 @@
+package silences
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/kubernetes"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// maintenanceTag matches the tag startMaintenance puts in the comment of its
+// silences, e.g. "[maintenance mnt-1a2b3c4d]".
+var maintenanceTag = regexp.MustCompile(`\[maintenance ([\w-]+)\]`)
+
+type startMaintenanceArgs struct {
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace under maintenance"`
+	Node      string `json:"node,omitempty" jsonschema:"Kubernetes node under maintenance"`
+	Matchers  string `json:"matchers,omitempty" jsonschema:"Any other label scope under maintenance, e.g. '{cluster=\"eu-1\", team=\"db\"}'"`
+	silenceArgs
+	output.FormatArgs
+}
+
+func registerStartMaintenance(s *mcp.Server, client *alertmanager.Client, cluster *kubernetes.Cluster) {
+	input := mcputil.NewInput[startMaintenanceArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "startMaintenance",
+		Description: "Put a Kubernetes namespace, node or label scope (exactly one) into maintenance: creates silences tagged with a shared maintenance ID in their comment, to end them together with endMaintenance. A node covers alerts labelled node=<node>, alerts whose instance is the node name or one of its addresses, and alerts of the pods running on it. Max 30 days.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Silences: Start Maintenance",
+			ReadOnlyHint:    false,
+			DestructiveHint: ptr.To(false),
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[maintenance](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		var scope string
+		var matcherSets [][]alertmanager.Matcher
+		var notes []string
+		switch {
+		case countSet(args.Namespace, args.Node, args.Matchers) != 1:
+			return mcputil.NewErrorResult("exactly one of namespace, node or matchers is required"), nil
+		case args.Namespace != "":
+			scope = "namespace " + args.Namespace
+			matcherSets = [][]alertmanager.Matcher{{equal("namespace", args.Namespace)}}
+		case args.Node != "":
+			scope = "node " + args.Node
+			var node *kubernetes.Node
+			if cluster == nil {
+				node = &kubernetes.Node{Name: args.Node}
+				notes = append(notes, "No Kubernetes cluster is configured: only alerts labelled with the node name are covered, not those labelled with its addresses or pods.")
+			} else {
+				if node, err = cluster.Node(ctx, args.Node); err != nil {
+					return mcputil.NewErrorResult(fmt.Sprintf("Failed to look up node: %v", err)), nil
+				}
+				notes = append(notes, fmt.Sprintf("Node %s: %d address(es), %d pod(s).", node.Name, len(node.Addresses), len(node.Pods)))
+			}
+			matcherSets = nodeMatchers(node)
+		default:
+			scope = args.Matchers
+			matchers, err := alertmanager.ParseMatchers(args.Matchers)
+			if err != nil {
+				return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"matchers\": %v", err)), nil
+			}
+			if len(matchers) == 0 {
+				return mcputil.NewErrorResult("matchers must not be empty: a silence without matchers would cover every alert"), nil
+			}
+			matcherSets = [][]alertmanager.Matcher{matchers}
+		}
+
+		id := newMaintenanceID()
+		if args.Comment == "" {
+			args.Comment = "Maintenance of " + scope
+		}
+		args.Comment = fmt.Sprintf("[maintenance %s] %s", id, args.Comment)
+		now := time.Now()
+		silences := make([]alertmanager.PostableSilence, len(matcherSets))
+		for i, matchers := range matcherSets {
+			if silences[i], err = args.newSilence(matchers, now); err != nil {
+				return mcputil.NewErrorResult(err.Error()), nil
+			}
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+		data := maintenance{ID: id, Scope: scope}
+		for _, a := range alerts {
+			if slices.ContainsFunc(matcherSets, func(m []alertmanager.Matcher) bool { return alertmanager.MatchAll(m, a.Labels) }) {
+				data.Alerts++
+			}
+		}
+
+		// Create all silences or none, so the maintenance is never half in place.
+		for i := range silences {
+			silenceID, err := client.CreateSilence(silences[i])
+			if err != nil {
+				for _, created := range silences[:i] {
+					_ = client.DeleteSilence(created.ID)
+				}
+				return mcputil.NewErrorResult(fmt.Sprintf("Failed to create silence %s, expired the %d silence(s) already created: %v",
+					alertmanager.FormatMatchers(silences[i].Matchers), i, err)), nil
+			}
+			silences[i].ID = silenceID
+		}
+		data.Silences = silences
+
+		notes = append(notes,
+			fmt.Sprintf("Maintenance %s of %s: created %d silence(s) covering %d current alert(s), until %s.",
+				id, scope, len(silences), data.Alerts, output.FormatTime(silences[0].EndsAt)),
+			fmt.Sprintf("End it early with endMaintenance id=%s.", id))
+		result, err := output.Render(args.Format, data, output.Report{
+			Title:  "Maintenance started",
+			Notes:  notes,
+			Tables: []output.Table{silenceTable(silences...)},
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format maintenance: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// maintenance is the structured result of startMaintenance.
+type maintenance struct {
+	ID       string                         `json:"id" jsonschema:"maintenance ID in the comment of each silence, to pass to endMaintenance"`
+	Scope    string                         `json:"scope"`
+	Silences []alertmanager.PostableSilence `json:"silences"`
+	Alerts   int                            `json:"alerts" jsonschema:"number of current alerts the silences cover"`
+}
+
+// nodeMatchers returns the matchers of the silences for a node: alerts
+// labelled with the node, alerts whose instance is the node or one of its
+// addresses (with or without port), and alerts of the pods on the node, one
+// silence per namespace.
+func nodeMatchers(node *kubernetes.Node) [][]alertmanager.Matcher {
+	hosts := []string{regexp.QuoteMeta(node.Name)}
+	for _, address := range node.Addresses {
+		hosts = append(hosts, regexp.QuoteMeta(address))
+	}
+	sets := [][]alertmanager.Matcher{
+		{equal("node", node.Name)},
+		{{IsEqual: true, IsRegex: true, Name: "instance", Value: "(" + strings.Join(hosts, "|") + ")(:[0-9]+)?"}},
+	}
+	var pods []string
+	for i, pod := range node.Pods {
+		pods = append(pods, regexp.QuoteMeta(pod.Name))
+		if i == len(node.Pods)-1 || node.Pods[i+1].Namespace != pod.Namespace {
+			sets = append(sets, []alertmanager.Matcher{
+				equal("namespace", pod.Namespace),
+				{IsEqual: true, IsRegex: true, Name: "pod", Value: strings.Join(pods, "|")},
+			})
+			pods = nil
+		}
+	}
+	return sets
+}
+
+type endMaintenanceArgs struct {
+	ID string `json:"id" jsonschema:"Maintenance ID returned by startMaintenance"`
+	output.FormatArgs
+}
+
+func registerEndMaintenance(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[endMaintenanceArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "endMaintenance",
+		Description: "End a maintenance started with startMaintenance: expires all active and pending silences tagged with its maintenance ID. Reports which silences were expired and which failed.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Silences: End Maintenance",
+			ReadOnlyHint:    false,
+			DestructiveHint: ptr.To(true),
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[expiredSilences](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		silences, err := client.GetSilences("")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get silences: %v", err)), nil
+		}
+		var selected []alertmanager.GettableSilence
+		var ongoing []string
+		for _, s := range silences {
+			if s.Status.State == "expired" {
+				continue
+			}
+			m := maintenanceTag.FindStringSubmatch(s.Comment)
+			if m == nil {
+				continue
+			}
+			if m[1] == args.ID {
+				selected = append(selected, s)
+			} else if !slices.Contains(ongoing, m[1]) {
+				ongoing = append(ongoing, m[1])
+			}
+		}
+		if len(selected) == 0 {
+			msg := fmt.Sprintf("No active or pending silences of maintenance %s.", args.ID)
+			if len(ongoing) > 0 {
+				slices.Sort(ongoing)
+				msg += " Ongoing maintenances: " + strings.Join(ongoing, ", ")
+			}
+			return mcputil.NewErrorResult(msg), nil
+		}
+		output.SortSilences(selected, "endsAt")
+
+		data := expiredSilences{Selected: len(selected), Silences: make([]expiredSilence, len(selected))}
+		for i, s := range selected {
+			data.Silences[i] = expiredSilence{
+				ID:        s.ID,
+				State:     s.Status.State,
+				Matchers:  alertmanager.FormatMatchers(s.Matchers),
+				CreatedBy: s.CreatedBy,
+				Comment:   s.Comment,
+				EndsAt:    s.EndsAt,
+			}
+			if err := client.DeleteSilence(s.ID); err != nil {
+				data.Silences[i].Error = err.Error()
+				data.Failed++
+				continue
+			}
+			data.Silences[i].Expired = true
+			data.Expired++
+		}
+
+		result, err := output.Render(args.Format, data, output.Report{
+			Title:  "Maintenance ended",
+			Notes:  []string{fmt.Sprintf("Maintenance %s: expired %d of %d silence(s), %d failed.", args.ID, data.Expired, len(selected), data.Failed)},
+			Tables: []output.Table{expiredTable(data)},
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format result: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// newMaintenanceID returns a random maintenance ID such as "mnt-1a2b3c4d".
+func newMaintenanceID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("mnt-%x", b)
+}
+
+func equal(name, value string) alertmanager.Matcher {
+	return alertmanager.Matcher{IsEqual: true, Name: name, Value: value}
+}
+
+// countSet returns how many of the values are not empty.
+func countSet(values ...string) int {
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,269 @@
+package silences
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+type suggestSilenceMatchersArgs struct {
+	Fingerprints []string `json:"fingerprints" jsonschema:"Fingerprints of the alerts to silence, as shown by getAlerts"`
+	output.FormatArgs
+}
+
+func registerSuggestSilenceMatchers(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[suggestSilenceMatchersArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "suggestSilenceMatchers",
+		Description: "Suggest the smallest set of silence matchers that covers exactly the given alerts (by fingerprint) and no other current alert. Uses equality matchers, and regex matchers only where equality cannot tell the alerts apart. Explains unavoidable collateral: other alerts any single silence for the given alerts would also cover.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:        "Silences: Suggest Silence Matchers",
+			ReadOnlyHint: true,
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[matcherSuggestion](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		if len(args.Fingerprints) == 0 {
+			return mcputil.NewErrorResult("fingerprints must not be empty"), nil
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+		var targets, others []alertmanager.GettableAlert
+		found := make(map[string]bool)
+		for _, a := range alerts {
+			if slices.Contains(args.Fingerprints, a.Fingerprint) {
+				targets = append(targets, a)
+				found[a.Fingerprint] = true
+			} else {
+				others = append(others, a)
+			}
+		}
+		var unknown []string
+		for _, fp := range args.Fingerprints {
+			if !found[fp] && !slices.Contains(unknown, fp) {
+				unknown = append(unknown, fp)
+			}
+		}
+		if len(unknown) > 0 {
+			return mcputil.NewErrorResult(fmt.Sprintf("No alert with fingerprint %s; use getAlerts to find them", strings.Join(unknown, ", "))), nil
+		}
+
+		matchers, collateral := suggestMatchers(targets, others)
+		data := matcherSuggestion{
+			Matchers: alertmanager.FormatMatchers(matchers),
+			Targets:  len(targets),
+			Exact:    len(collateral) == 0,
+		}
+		remaining := others
+		for _, m := range matchers {
+			var kept []alertmanager.GettableAlert
+			for _, a := range remaining {
+				if m.Matches(a.Labels) {
+					kept = append(kept, a)
+				}
+			}
+			kind := "equality"
+			if m.IsRegex {
+				kind = "regex"
+			}
+			data.Steps = append(data.Steps, matcherStep{Matcher: m.String(), Kind: kind, Excludes: len(remaining) - len(kept)})
+			remaining = kept
+		}
+		for _, a := range collateral {
+			data.Collateral = append(data.Collateral, collateralAlert{
+				Fingerprint: a.Fingerprint,
+				AlertName:   a.Labels["alertname"],
+				State:       a.Status.State,
+				Labels:      output.FormatLabels(output.Labels(a.Labels, nil), "alertname"),
+			})
+		}
+
+		notes := []string{"Suggested matchers: " + data.Matchers}
+		if data.Exact {
+			notes = append(notes, fmt.Sprintf("Covers exactly the %d given alert(s) and none of the %d other current alert(s).", len(targets), len(others)))
+		} else {
+			notes = append(notes,
+				fmt.Sprintf("Also covers %d other current alert(s). Each of their label values also occurs among the given alerts, so no single silence can tell them apart.", len(collateral)),
+				"To avoid the collateral, silence the given alerts one by one with silenceAlertInstance.")
+		}
+		notes = append(notes, "Alerts that start firing later with matching labels are silenced as well.")
+		tables := []output.Table{stepsTable(data.Steps)}
+		if len(collateral) > 0 {
+			tables = append(tables, collateralTable(data.Collateral))
+		}
+		result, err := output.Render(args.Format, data, output.Report{
+			Title:  "Silence Matcher Suggestion",
+			Notes:  notes,
+			Tables: tables,
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format suggestion: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// matcherSuggestion is the structured result of suggestSilenceMatchers.
+type matcherSuggestion struct {
+	Matchers   string            `json:"matchers" jsonschema:"suggested matchers in Alertmanager syntax"`
+	Steps      []matcherStep     `json:"steps"`
+	Targets    int               `json:"targets" jsonschema:"number of given alerts"`
+	Exact      bool              `json:"exact" jsonschema:"whether the matchers cover no other current alert"`
+	Collateral []collateralAlert `json:"collateral,omitempty" jsonschema:"other current alerts the matchers also cover"`
+}
+
+// matcherStep is a suggested matcher with the number of other alerts it rules
+// out on top of the matchers before it.
+type matcherStep struct {
+	Matcher  string `json:"matcher"`
+	Kind     string `json:"kind" jsonschema:"equality or regex"`
+	Excludes int    `json:"excludes" jsonschema:"number of other current alerts this matcher rules out"`
+}
+
+// collateralAlert is another alert the suggested matchers cover.
+type collateralAlert struct {
+	Fingerprint string `json:"fingerprint"`
+	AlertName   string `json:"alertName"`
+	State       string `json:"state"`
+	Labels      string `json:"labels"`
+}
+
+// suggestMatchers returns the smallest matchers it finds that match all
+// targets and as few others as possible, and the others they still match.
+//
+// Each label has one candidate matcher: equality if all targets share the
+// value, a regex of the target values otherwise (a missing label counts as the
+// empty value). Candidates are added greedily by how many others they rule
+// out, equality matchers before regex matchers, then matchers made redundant
+// by later ones are dropped. The alertname is always kept when the targets
+// share it, so the silence does not catch unrelated alerts that start firing
+// later.
+func suggestMatchers(targets, others []alertmanager.GettableAlert) ([]alertmanager.Matcher, []alertmanager.GettableAlert) {
+	names := make(map[string]bool)
+	for _, a := range slices.Concat(targets, others) {
+		for name := range a.Labels {
+			names[name] = true
+		}
+	}
+	// Matchers on labels the targets have come before matchers on missing labels.
+	var equality, missing, regex []alertmanager.Matcher
+	var alertname alertmanager.Matcher
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		m := candidateMatcher(name, targets)
+		switch {
+		case name == "alertname":
+			alertname = m
+		case m.IsRegex:
+			regex = append(regex, m)
+		case m.Value == "":
+			missing = append(missing, m)
+		default:
+			equality = append(equality, m)
+		}
+	}
+	equality = append(equality, missing...)
+
+	var matchers []alertmanager.Matcher
+	remaining := others
+	if !alertname.IsRegex {
+		matchers = append(matchers, alertname)
+		remaining = matching([]alertmanager.Matcher{alertname}, remaining)
+	} else {
+		regex = append([]alertmanager.Matcher{alertname}, regex...)
+	}
+	for _, candidates := range [][]alertmanager.Matcher{equality, regex} {
+		for len(remaining) > 0 {
+			best, excluded := -1, 0
+			for i, m := range candidates {
+				if n := len(remaining) - len(matching([]alertmanager.Matcher{m}, remaining)); n > excluded {
+					best, excluded = i, n
+				}
+			}
+			if best < 0 {
+				break
+			}
+			matchers = append(matchers, candidates[best])
+			remaining = matching([]alertmanager.Matcher{candidates[best]}, remaining)
+			candidates = slices.Delete(candidates, best, best+1)
+		}
+	}
+
+	// Drop redundant matchers, the last added first, keeping the alertname.
+	for i := len(matchers) - 1; i >= 0; i-- {
+		if matchers[i] == alertname && !alertname.IsRegex {
+			continue
+		}
+		without := slices.Delete(slices.Clone(matchers), i, i+1)
+		if len(without) > 0 && len(matching(without, others)) == len(remaining) {
+			matchers = without
+		}
+	}
+	// Alertmanager rejects silences whose matchers all match the empty value.
+	if !slices.ContainsFunc(matchers, func(m alertmanager.Matcher) bool { return !m.Matches(nil) }) {
+		matchers = append([]alertmanager.Matcher{alertname}, matchers...)
+	}
+	return matchers, remaining
+}
+
+// candidateMatcher returns the matcher on a label that matches exactly the
+// values of the targets.
+func candidateMatcher(name string, targets []alertmanager.GettableAlert) alertmanager.Matcher {
+	values := make(map[string]bool)
+	for _, a := range targets {
+		values[a.Labels[name]] = true
+	}
+	if len(values) == 1 {
+		for value := range values {
+			return alertmanager.Matcher{IsEqual: true, Name: name, Value: value}
+		}
+	}
+	quoted := make([]string, 0, len(values))
+	for _, value := range slices.Sorted(maps.Keys(values)) {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+	return alertmanager.Matcher{IsEqual: true, IsRegex: true, Name: name, Value: strings.Join(quoted, "|")}
+}
+
+// matching returns the alerts all matchers match.
+func matching(matchers []alertmanager.Matcher, alerts []alertmanager.GettableAlert) []alertmanager.GettableAlert {
+	var matched []alertmanager.GettableAlert
+	for _, a := range alerts {
+		if alertmanager.MatchAll(matchers, a.Labels) {
+			matched = append(matched, a)
+		}
+	}
+	return matched
+}
+
+func stepsTable(steps []matcherStep) output.Table {
+	t := output.Table{Title: "Matchers", Columns: []string{"matcher", "kind", "excludes"}}
+	for _, s := range steps {
+		t.Rows = append(t.Rows, []string{s.Matcher, s.Kind, strconv.Itoa(s.Excludes)})
+	}
+	return t
+}
+
+func collateralTable(alerts []collateralAlert) output.Table {
+	t := output.Table{Title: "Collateral", Columns: []string{"fingerprint", "alertname", "state", "labels"}}
+	for _, a := range alerts {
+		t.Rows = append(t.Rows, []string{a.Fingerprint, a.AlertName, a.State, a.Labels})
+	}
+	return t
+}
@@ -0,0 +1,79 @@
+package silences
+
+import (
+	"testing"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+func alert(labels ...string) alertmanager.GettableAlert {
+	a := alertmanager.GettableAlert{Labels: make(map[string]string)}
+	for i := 0; i+1 < len(labels); i += 2 {
+		a.Labels[labels[i]] = labels[i+1]
+	}
+	return a
+}
+
+func TestSuggestMatchers(t *testing.T) {
+	tests := []struct {
+		name       string
+		targets    []alertmanager.GettableAlert
+		others     []alertmanager.GettableAlert
+		want       string
+		collateral int
+	}{
+		{
+			name:    "alertname only",
+			targets: []alertmanager.GettableAlert{alert("alertname", "Down", "pod", "a")},
+			others:  []alertmanager.GettableAlert{alert("alertname", "Up", "pod", "a")},
+			want:    `{alertname="Down"}`,
+		},
+		{
+			name:    "distinguishing label",
+			targets: []alertmanager.GettableAlert{alert("alertname", "Down", "namespace", "prod", "pod", "a")},
+			others: []alertmanager.GettableAlert{
+				alert("alertname", "Down", "namespace", "dev", "pod", "a"),
+				alert("alertname", "Down", "namespace", "dev", "pod", "b"),
+			},
+			want: `{alertname="Down", namespace="prod"}`,
+		},
+		{
+			name: "regex of target values",
+			targets: []alertmanager.GettableAlert{
+				alert("alertname", "Down", "pod", "a"),
+				alert("alertname", "Down", "pod", "b.c"),
+			},
+			others: []alertmanager.GettableAlert{alert("alertname", "Down", "pod", "d")},
+			want:   `{alertname="Down", pod=~"a|b\\.c"}`,
+		},
+		{
+			name:    "missing label",
+			targets: []alertmanager.GettableAlert{alert("alertname", "Down")},
+			others:  []alertmanager.GettableAlert{alert("alertname", "Down", "canary", "true")},
+			want:    `{alertname="Down", canary=""}`,
+		},
+		{
+			name:       "collateral",
+			targets:    []alertmanager.GettableAlert{alert("alertname", "Down", "pod", "a")},
+			others:     []alertmanager.GettableAlert{alert("alertname", "Down", "pod", "a"), alert("alertname", "Down", "pod", "b")},
+			want:       `{alertname="Down", pod="a"}`,
+			collateral: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchers, remaining := suggestMatchers(tt.targets, tt.others)
+			if got := alertmanager.FormatMatchers(matchers); got != tt.want {
+				t.Errorf("matchers = %s, want %s", got, tt.want)
+			}
+			if len(remaining) != tt.collateral {
+				t.Errorf("collateral = %d alerts, want %d", len(remaining), tt.collateral)
+			}
+			for _, a := range tt.targets {
+				if !alertmanager.MatchAll(matchers, a.Labels) {
+					t.Errorf("matchers do not match target %v", a.Labels)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package silences
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// volatileLabels are labels whose values change when the workload restarts.
+var volatileLabels = map[string]bool{"container_id": true, "image_id": true, "pod_uid": true, "uid": true}
+
+// Generated names and identifiers: pods of deployments, daemonsets and jobs
+// (Kubernetes random suffixes use no vowels), UUIDs and long hex IDs.
+var volatileValue = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}$|^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$|^[0-9a-f]{12,}$`)
+
+// isVolatile reports whether a label is likely to change for the same problem,
+// such as a pod name with a generated suffix.
+func isVolatile(name, value string) bool {
+	return volatileLabels[name] || volatileValue.MatchString(value)
+}
+
+// targetArgs select the labels of the matchers of a targeted silence.
+type targetArgs struct {
+	OmitLabels   []string `json:"omitLabels,omitempty" jsonschema:"Labels to leave out of the matchers"`
+	OmitVolatile bool     `json:"omitVolatile,omitempty" jsonschema:"Leave out labels that change on restart, like pod names with generated suffixes, container IDs and UIDs, so the silence survives the workload being recreated (default: false)"`
+}
+
+// matchers returns equality matchers for labels, sorted by name, without the
+// omitted labels, and the names of the omitted labels.
+func (a targetArgs) matchers(labels map[string]string) ([]alertmanager.Matcher, []string) {
+	var matchers []alertmanager.Matcher
+	var omitted []string
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		value := labels[name]
+		if name != "alertname" && (slices.Contains(a.OmitLabels, name) || a.OmitVolatile && isVolatile(name, value)) {
+			omitted = append(omitted, name)
+			continue
+		}
+		matchers = append(matchers, alertmanager.Matcher{IsEqual: true, Name: name, Value: value})
+	}
+	return matchers, omitted
+}
+
+// targetedSilence is the structured result of silenceAlertGroup and
+// silenceAlertInstance.
+type targetedSilence struct {
+	Silence alertmanager.PostableSilence `json:"silence"`
+	Omitted []string                     `json:"omitted,omitempty" jsonschema:"labels left out of the matchers"`
+	Alerts  int                          `json:"alerts" jsonschema:"number of current alerts the silence covers"`
+}
+
+type silenceAlertGroupArgs struct {
+	GroupLabels map[string]string `json:"groupLabels" jsonschema:"Labels of the alert group, as shown by getAlertGroups"`
+	Receiver    string            `json:"receiver,omitempty" jsonschema:"Receiver of the alert group, to pick one of several groups with the same labels"`
+	targetArgs
+	silenceArgs
+	output.FormatArgs
+}
+
+func registerSilenceAlertGroup(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[silenceAlertGroupArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "silenceAlertGroup",
+		Description: "Silence exactly one alert group from getAlertGroups: creates equality matchers on the group labels. Silences match labels only, so groups with the same labels for other receivers are silenced too. Max 30 days.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Silences: Silence Alert Group",
+			ReadOnlyHint:    false,
+			DestructiveHint: ptr.To(false),
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[targetedSilence](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		if len(args.GroupLabels) == 0 {
+			return mcputil.NewErrorResult("groupLabels must not be empty: a silence without matchers would cover every alert"), nil
+		}
+
+		groups, err := client.GetAlertGroupsRaw()
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alert groups: %v", err)), nil
+		}
+		group, receivers := findGroup(groups, args.GroupLabels, args.Receiver)
+		if group == nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("No alert group with labels %s%s; use getAlertGroups to find the group labels",
+				output.FormatLabels(args.GroupLabels), receiverSuffix(args.Receiver))), nil
+		}
+
+		matchers, omitted := args.matchers(group.Labels)
+		if len(matchers) == 0 {
+			return mcputil.NewErrorResult("all group labels were omitted: a silence without matchers would cover every alert"), nil
+		}
+		notes := []string{fmt.Sprintf("Group of receiver %s with %d alert(s).", group.Receiver.Name, len(group.Alerts))}
+		if len(receivers) > 1 {
+			notes = append(notes, fmt.Sprintf("Groups with the same labels for receivers %s are silenced as well.", strings.Join(receivers, ", ")))
+		}
+		return createTargetedSilence(client, args.silenceArgs, args.Format, matchers, omitted, notes)
+	})
+}
+
+// findGroup returns the first group with the given labels, of the given
+// receiver if not empty, and the receivers of all groups with these labels.
+// The labels match with or without the labels getAlertGroups hides.
+func findGroup(groups []alertmanager.AlertGroup, labels map[string]string, receiver string) (*alertmanager.AlertGroup, []string) {
+	var group *alertmanager.AlertGroup
+	var receivers []string
+	for i, g := range groups {
+		if !maps.Equal(g.Labels, labels) && !maps.Equal(output.Labels(g.Labels, nil), labels) {
+			continue
+		}
+		receivers = append(receivers, g.Receiver.Name)
+		if group == nil && (receiver == "" || g.Receiver.Name == receiver) {
+			group = &groups[i]
+		}
+	}
+	return group, receivers
+}
+
+type silenceAlertInstanceArgs struct {
+	Fingerprint string `json:"fingerprint" jsonschema:"Fingerprint of the alert instance, as shown by getAlerts"`
+	targetArgs
+	silenceArgs
+	output.FormatArgs
+}
+
+func registerSilenceAlertInstance(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[silenceAlertInstanceArgs]()
+	s.AddTool(&mcp.Tool{
+		Name:        "silenceAlertInstance",
+		Description: "Silence exactly one alert instance by fingerprint: creates equality matchers on all of its labels, optionally leaving out volatile labels like pod names so the silence survives restarts. Max 30 days.",
+		Annotations: &mcp.ToolAnnotations{
+			Title:           "Silences: Silence Alert Instance",
+			ReadOnlyHint:    false,
+			DestructiveHint: ptr.To(false),
+		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[targetedSilence](),
+	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		alerts, err := client.GetAlertsRaw("true", "true", "true")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+		}
+		i := slices.IndexFunc(alerts, func(a alertmanager.GettableAlert) bool { return a.Fingerprint == args.Fingerprint })
+		if i < 0 {
+			return mcputil.NewErrorResult(fmt.Sprintf("No alert with fingerprint %s; use getAlerts to find it", args.Fingerprint)), nil
+		}
+		alert := alerts[i]
+
+		matchers, omitted := args.matchers(alert.Labels)
+		notes := []string{fmt.Sprintf("Alert %s (%s), %s since %s.", alert.Labels["alertname"], alert.Fingerprint, alert.Status.State, output.FormatTime(alert.StartsAt))}
+		return createTargetedSilence(client, args.silenceArgs, args.Format, matchers, omitted, notes)
+	})
+}
+
+// createTargetedSilence creates a silence with the given matchers and reports
+// which current alerts it covers.
+func createTargetedSilence(client *alertmanager.Client, args silenceArgs, format output.Format, matchers []alertmanager.Matcher, omitted, notes []string) (*mcp.CallToolResult, error) {
+	silence, err := args.newSilence(matchers, time.Now())
+	if err != nil {
+		return mcputil.NewErrorResult(err.Error()), nil
+	}
+	alerts, err := client.GetAlertsRaw("true", "true", "true")
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+	}
+	covered := make(map[string]int)
+	data := targetedSilence{Omitted: omitted}
+	for _, a := range alerts {
+		if alertmanager.MatchAll(matchers, a.Labels) {
+			covered[a.Labels["alertname"]]++
+			data.Alerts++
+		}
+	}
+
+	silenceID, err := client.CreateSilence(silence)
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("Failed to create silence: %v", err)), nil
+	}
+	silence.ID = silenceID
+	data.Silence = silence
+
+	if len(omitted) > 0 {
+		notes = append(notes, "Labels left out of the matchers: "+strings.Join(omitted, ", "))
+	}
+	notes = append(notes, fmt.Sprintf("Covers %d current alert(s).", data.Alerts))
+	result, err := output.Render(format, data, output.Report{
+		Title:  "Silence created successfully",
+		Notes:  notes,
+		Tables: []output.Table{silenceTable(silence), output.CountTable("Covered Alerts", "alertname", "alerts", covered)},
+	})
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("Failed to format silence: %v", err)), nil
+	}
+	return mcputil.NewStructuredResult(result, data), nil
+}
+
+func receiverSuffix(receiver string) string {
+	if receiver == "" {
+		return ""
+	}
+	return " for receiver " + receiver
+}
@@ -0,0 +1,45 @@
+package silences
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+func TestFindGroup(t *testing.T) {
+	output.SetFieldFilter(output.FieldFilter{LabelDeny: []string{"cluster"}})
+	t.Cleanup(func() { output.SetFieldFilter(output.FieldFilter{}) })
+
+	groups := []alertmanager.AlertGroup{
+		{Labels: map[string]string{"alertname": "Down", "cluster": "eu"}, Receiver: alertmanager.Receiver{Name: "team"}},
+		{Labels: map[string]string{"alertname": "Down", "cluster": "eu"}, Receiver: alertmanager.Receiver{Name: "pager"}},
+		{Labels: map[string]string{"alertname": "Up", "cluster": "eu"}, Receiver: alertmanager.Receiver{Name: "team"}},
+	}
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		receiver  string
+		want      string
+		receivers []string
+	}{
+		{"visible labels", map[string]string{"alertname": "Down"}, "", "team", []string{"team", "pager"}},
+		{"receiver", map[string]string{"alertname": "Down"}, "pager", "pager", []string{"team", "pager"}},
+		{"hidden label shown with fields", map[string]string{"alertname": "Down", "cluster": "eu"}, "", "team", []string{"team", "pager"}},
+		{"wrong hidden label", map[string]string{"alertname": "Down", "cluster": "us"}, "", "", nil},
+		{"no such group", map[string]string{"alertname": "Gone"}, "", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, receivers := findGroup(groups, tt.labels, tt.receiver)
+			got := ""
+			if group != nil {
+				got = group.Receiver.Name
+			}
+			if got != tt.want || !slices.Equal(receivers, tt.receivers) {
+				t.Errorf("findGroup() = %q, %v, want %q, %v", got, receivers, tt.want, tt.receivers)
+			}
+		})
+	}
+}
@@ -4,14 +4,20 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+type getReceiversArgs struct {
+	output.PageArgs
+	output.FormatArgs
+}
+
 func registerGetReceivers(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getReceiversArgs](output.PageSchema("name"))
 	s.AddTool(&mcp.Tool{
 		Name:        "getReceivers",
 		Description: "List configured notification receivers (Slack, email, PagerDuty, etc.).",
@@ -19,14 +25,32 @@ func registerGetReceivers(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Status: Get Receivers",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[output.List[alertmanager.Receiver]](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		result, err := client.GetReceivers()
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		page := args.Page()
+
+		receivers, err := client.GetReceivers()
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get receivers: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+		if err := output.SortReceivers(receivers, page.SortBy); err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+		var list output.List[alertmanager.Receiver]
+		result, err := output.Paginate(receivers, page, "receivers", args.Format, func(items []alertmanager.Receiver) (string, any, error) {
+			list = output.List[alertmanager.Receiver]{Items: items, Total: len(receivers), Offset: page.Offset}
+			return output.RenderPage(args.Format, list, output.Report{
+				Tables: []output.Table{output.ReceiversTable("", items)},
+			})
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format receivers: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, list), nil
 	})
 }
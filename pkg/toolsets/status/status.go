@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
 // Register registers all status-related tools.
@@ -17,7 +17,12 @@ func Register(s *mcp.Server, client *alertmanager.Client) {
 	registerGetReceivers(s, client)
 }
 
+type getStatusArgs struct {
+	output.FormatArgs
+}
+
 func registerGetStatus(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[getStatusArgs]()
 	s.AddTool(&mcp.Tool{
 		Name:        "getAlertmanagerStatus",
 		Description: "Get Alertmanager server status: version, uptime, cluster info.",
@@ -25,14 +30,45 @@ func registerGetStatus(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Status: Get Alertmanager Status",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[alertmanager.AlertmanagerStatus](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		result, err := client.GetStatus()
+		args, err := input.Bind(request)
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		status, err := client.GetStatusRaw()
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get status: %v", err)), nil
 		}
-		return mcputil.NewTextResult(result), nil
+
+		peers := output.Table{Title: "Cluster Peers", Columns: []string{"name", "address"}}
+		for _, p := range status.Cluster.Peers {
+			peers.Rows = append(peers.Rows, []string{p.Name, p.Address})
+		}
+		result, err := output.Render(args.Format, status, output.Report{
+			Title: "Alertmanager Status",
+			Notes: []string{"Use format=json to include the running configuration."},
+			Tables: []output.Table{
+				{
+					Columns: []string{"field", "value"},
+					Rows: [][]string{
+						{"version", status.VersionInfo.Version},
+						{"revision", status.VersionInfo.Revision},
+						{"branch", status.VersionInfo.Branch},
+						{"goVersion", status.VersionInfo.GoVersion},
+						{"uptime", output.FormatTime(status.Uptime)},
+						{"cluster", status.Cluster.Name},
+						{"clusterStatus", status.Cluster.Status},
+					},
+				},
+				peers,
+			},
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format status: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, status), nil
 	})
 }
@@ -1,19 +1,27 @@
 package troubleshooting
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"sort"
-	"strings"
+	"strconv"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
+type correlateAlertsArgs struct {
+	output.PageArgs
+	output.FieldsArgs
+	output.FormatArgs
+}
+
 func registerCorrelateAlerts(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[correlateAlertsArgs](output.PageSchema("size, key (default: -size)"))
 	s.AddTool(&mcp.Tool{
 		Name:        "correlateAlerts",
 		Description: "Find correlated alerts that share common labels (namespace, pod, node). Helps identify related issues during incidents.",
@@ -21,26 +29,34 @@ func registerCorrelateAlerts(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Troubleshooting: Correlate Alerts",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[correlation](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		alerts, err := client.GetAlertsRaw("true", "", "")
+		args, err := input.Bind(request)
 		if err != nil {
-			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		page := args.Page()
+		if page.SortBy == "" {
+			page.SortBy = "-size"
 		}
 
-		if len(alerts) == 0 {
-			return mcputil.NewTextResult("No active alerts to correlate."), nil
+		alerts, err := client.GetAlertsRaw("true", "", "")
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
 		}
 
 		// Correlation labels to check
 		correlationLabels := []string{"namespace", "pod", "node", "service", "job", "instance"}
 
-		// Group alerts by correlation labels
+		// Group alerts by correlation labels, skipping labels hidden from the client
 		groups := make(map[string][]alertmanager.GettableAlert)
 		for _, alert := range alerts {
 			for _, label := range correlationLabels {
+				if !output.LabelVisible(label, args.Fields) {
+					continue
+				}
 				if val, exists := alert.Labels[label]; exists && val != "" {
 					key := fmt.Sprintf("%s=%s", label, val)
 					groups[key] = append(groups[key], alert)
@@ -48,38 +64,90 @@ func registerCorrelateAlerts(s *mcp.Server, client *alertmanager.Client) {
 			}
 		}
 
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("=== Alert Correlation ===\nTotal Active Alerts: %d\n\n", len(alerts)))
-
 		// Sort groups by number of alerts (most correlated first)
-		type groupEntry struct {
-			key    string
-			alerts []alertmanager.GettableAlert
-		}
-		var sortedGroups []groupEntry
+		var sortedGroups []correlationGroup
 		for k, v := range groups {
 			if len(v) > 1 { // Only show groups with 2+ alerts
-				sortedGroups = append(sortedGroups, groupEntry{k, v})
+				sortedGroups = append(sortedGroups, correlationGroup{Key: k, Alerts: v})
 			}
 		}
 		sort.Slice(sortedGroups, func(i, j int) bool {
-			return len(sortedGroups[i].alerts) > len(sortedGroups[j].alerts)
+			return sortedGroups[i].Key < sortedGroups[j].Key
 		})
-
-		if len(sortedGroups) == 0 {
-			sb.WriteString("No correlated alerts found (no shared labels between alerts).\n")
-		} else {
-			for _, g := range sortedGroups {
-				sb.WriteString(fmt.Sprintf("--- %s (%d alerts) ---\n", g.key, len(g.alerts)))
-				for _, a := range g.alerts {
-					severity := a.Labels["severity"]
-					sb.WriteString(fmt.Sprintf("  - %s [%s] (%s)\n",
-						a.Labels["alertname"], severity, a.Status.State))
-				}
-				sb.WriteString("\n")
+		err = output.Sort(sortedGroups, page.SortBy, func(key string) func(a, b correlationGroup) int {
+			switch key {
+			case "size":
+				return func(a, b correlationGroup) int { return cmp.Compare(len(a.Alerts), len(b.Alerts)) }
+			case "key":
+				return func(a, b correlationGroup) int { return cmp.Compare(a.Key, b.Key) }
+			default:
+				return nil
 			}
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
 		}
 
-		return mcputil.NewTextResult(sb.String()), nil
+		var data correlation
+		result, err := output.Paginate(sortedGroups, page, "correlation groups", args.Format, func(items []correlationGroup) (string, any, error) {
+			data = correlation{
+				ActiveAlerts: len(alerts),
+				Total:        len(sortedGroups),
+				Offset:       page.Offset,
+				Groups:       make([]correlationGroup, len(items)),
+			}
+			for i, g := range items {
+				data.Groups[i] = correlationGroup{Key: g.Key, Alerts: output.Alerts(g.Alerts, args.Fields)}
+			}
+			report := output.Report{
+				Title: "Alert Correlation",
+				Notes: []string{fmt.Sprintf("Total Active Alerts: %d", len(alerts))},
+			}
+			switch {
+			case len(alerts) == 0:
+				report.Notes = append(report.Notes, "No active alerts to correlate.")
+			case len(sortedGroups) == 0:
+				report.Notes = append(report.Notes, "No correlated alerts found (no shared labels between alerts).")
+			default:
+				report.Tables = []output.Table{correlationTable(data.Groups)}
+			}
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format correlations: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
 	})
 }
+
+// correlation is the structured result of correlateAlerts.
+type correlation struct {
+	ActiveAlerts int                `json:"activeAlerts"`
+	Total        int                `json:"total" jsonschema:"number of correlation groups across all pages"`
+	Offset       int                `json:"offset" jsonschema:"index of the first returned group"`
+	Groups       []correlationGroup `json:"groups"`
+}
+
+// correlationGroup is a set of alerts sharing one correlation label value.
+type correlationGroup struct {
+	Key    string                       `json:"key"`
+	Alerts []alertmanager.GettableAlert `json:"alerts"`
+}
+
+func correlationTable(groups []correlationGroup) output.Table {
+	t := output.Table{
+		Columns: []string{"group", "size", "alertname", "severity", "state"},
+	}
+	for _, g := range groups {
+		for _, a := range g.Alerts {
+			t.Rows = append(t.Rows, []string{
+				g.Key,
+				strconv.Itoa(len(g.Alerts)),
+				a.Labels["alertname"],
+				a.Labels["severity"],
+				a.Status.State,
+			})
+		}
+	}
+	return t
+}
@@ -1,56 +1,62 @@
 package troubleshooting
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/timeutil"
 )
 
-func registerGetAlertHistory(s *mcp.Server, client *alertmanager.Client) {
+type getAlertHistoryArgs struct {
+	AlertName string `json:"alertName" jsonschema:"Alert name to get history for"`
+	Window    string `json:"window,omitempty" jsonschema:"How far back to look when alert history is enabled: '30m', '1d12h', '2w' (default: 7d)"`
+	output.PageArgs
+	output.FormatArgs
+}
+
+func registerGetAlertHistory(s *mcp.Server, client *alertmanager.Client, source history.Source) {
+	sortKeys := "startsAt, severity, state, or any label name"
+	if source != nil {
+		sortKeys = "startsAt, endsAt, duration, or any label name (default: -startsAt)"
+	}
+	input := mcputil.NewInput[getAlertHistoryArgs](output.PageSchema(sortKeys))
 	s.AddTool(&mcp.Tool{
 		Name:        "getAlertHistory",
-		Description: "Get alert history for a specific alert. Shows current/recent instances and guidance for historical analysis.",
+		Description: "Get alert history for a specific alert. With alert history enabled (history file or Prometheus): how many times it fired in a time window, for how long, and each occurrence with its state transitions. Otherwise shows current instances and guidance for historical analysis.",
 		Annotations: &mcp.ToolAnnotations{
 			Title:        "Troubleshooting: Get Alert History",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"alertName": {
-					Type:        "string",
-					Description: "Alert name to get history for",
-				},
-			},
-			Required: []string{"alertName"},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[alertHistory](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
 
-		alertName, _ := args["alertName"].(string)
+		alertName := args.AlertName
 		if alertName == "" {
 			return mcputil.NewErrorResult("alertName parameter is required"), nil
 		}
 
+		page := args.Page()
+
 		// Get all alerts (all states)
 		alerts, err := client.GetAlertsRaw("true", "true", "true")
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
 		}
 
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("=== Alert History: %s ===\n\n", alertName))
-
 		var matching []alertmanager.GettableAlert
 		for _, a := range alerts {
 			if a.Labels["alertname"] == alertName {
@@ -58,29 +64,250 @@ func registerGetAlertHistory(s *mcp.Server, client *alertmanager.Client) {
 			}
 		}
 
-		if len(matching) == 0 {
-			sb.WriteString("No current instances found.\n\n")
-		} else {
-			sb.WriteString(fmt.Sprintf("Current Instances: %d\n\n", len(matching)))
-			for i, a := range matching {
-				sb.WriteString(fmt.Sprintf("Instance %d:\n", i+1))
-				sb.WriteString(fmt.Sprintf("  State: %s\n", a.Status.State))
-				sb.WriteString(fmt.Sprintf("  Started: %s\n", a.StartsAt.Format(time.RFC3339)))
-				sb.WriteString(fmt.Sprintf("  Duration: %s\n", time.Since(a.StartsAt).Truncate(time.Second)))
-				sb.WriteString(fmt.Sprintf("  Severity: %s\n", a.Labels["severity"]))
-				if ns := a.Labels["namespace"]; ns != "" {
-					sb.WriteString(fmt.Sprintf("  Namespace: %s\n", ns))
-				}
-				sb.WriteString("\n")
+		if source != nil {
+			return recordedHistory(ctx, source, args, len(matching))
+		}
+
+		if err := output.SortAlerts(matching, page.SortBy); err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
+		}
+
+		guidance := []string{
+			"Alertmanager only stores current/active alerts. Start the server with --history-file or --prometheus-url for alert history, or query Prometheus with:",
+			fmt.Sprintf("  ALERTS{alertname=\"%s\"}", alertName),
+			fmt.Sprintf("  ALERTS_FOR_STATE{alertname=\"%s\"}", alertName),
+		}
+		var data alertHistory
+		result, err := output.Paginate(matching, page, "instances", args.Format, func(items []alertmanager.GettableAlert) (string, any, error) {
+			data = alertHistory{
+				AlertName: alertName,
+				Current:   len(matching),
+				Offset:    page.Offset,
+				Instances: output.Alerts(items, nil),
+			}
+			report := output.Report{Title: "Alert History: " + alertName}
+			if len(matching) == 0 {
+				report.Notes = []string{"No current instances found."}
+			} else {
+				report.Notes = []string{fmt.Sprintf("Current Instances: %d", len(matching))}
+				report.Tables = []output.Table{historyTable(data.Instances)}
 			}
+			report.Notes = append(report.Notes, guidance...)
+			return output.RenderPage(args.Format, data, report)
+		})
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format instances: %v", err)), nil
 		}
+		return mcputil.NewStructuredResult(result, data), nil
+	})
+}
+
+// recordedHistory answers getAlertHistory from the alert history.
+func recordedHistory(ctx context.Context, source history.Source, args getAlertHistoryArgs, current int) (*mcp.CallToolResult, error) {
+	window := "7d"
+	if args.Window != "" {
+		window = args.Window
+	}
+	d, err := timeutil.ParseDuration(window)
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("invalid argument \"window\": %v", err)), nil
+	}
+	now := time.Now()
+	since := now.Add(-d)
+
+	occurrences, err := source.Occurrences(ctx, args.AlertName, since)
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alert history: %v", err)), nil
+	}
+	summary := summarize(occurrences, window, since, now)
+	summary.Source = source.Name()
+	summary.RecordingSince = source.RecordedSince()
+
+	page := args.Page()
+	if page.SortBy == "" {
+		page.SortBy = "-startsAt"
+	}
+	if err := sortOccurrences(occurrences, page.SortBy, now); err != nil {
+		return mcputil.NewErrorResult(err.Error()), nil
+	}
 
-		sb.WriteString("--- Historical Analysis Guidance ---\n")
-		sb.WriteString("Alertmanager only stores current/active alerts.\n")
-		sb.WriteString("For historical alert data, query Prometheus with:\n")
-		sb.WriteString(fmt.Sprintf("  ALERTS{alertname=\"%s\"}\n", alertName))
-		sb.WriteString(fmt.Sprintf("  ALERTS_FOR_STATE{alertname=\"%s\"}\n", alertName))
+	notes := []string{
+		fmt.Sprintf("Last %s: fired %d time(s), firing for %s in total (longest %s, average %s).",
+			window, summary.Fired, output.FormatSeconds(summary.FiringSeconds),
+			output.FormatSeconds(summary.LongestSeconds), output.FormatSeconds(summary.AverageSeconds)),
+		fmt.Sprintf("Occurrences in window: %d, firing now: %d, silenced at some point: %d, current instances in Alertmanager: %d",
+			summary.Occurrences, summary.FiringNow, summary.Silenced, current),
+		"Source: " + summary.Source,
+	}
+	if summary.RecordingSince.After(since) {
+		notes = append(notes, fmt.Sprintf("History is only recorded since %s, so the window is not fully covered.", output.FormatTime(summary.RecordingSince)))
+	}
 
-		return mcputil.NewTextResult(sb.String()), nil
+	var data alertHistory
+	result, err := output.Paginate(occurrences, page, "occurrences", args.Format, func(items []history.Occurrence) (string, any, error) {
+		for i := range items {
+			items[i].Labels = output.Labels(items[i].Labels, nil)
+		}
+		summary.Items = items
+		data = alertHistory{
+			AlertName: args.AlertName,
+			Current:   current,
+			Offset:    page.Offset,
+			History:   &summary,
+		}
+		report := output.Report{Title: "Alert History: " + args.AlertName, Notes: notes}
+		if len(occurrences) > 0 {
+			report.Tables = []output.Table{occurrencesTable(items, now)}
+		}
+		return output.RenderPage(args.Format, data, report)
 	})
+	if err != nil {
+		return mcputil.NewErrorResult(fmt.Sprintf("Failed to format history: %v", err)), nil
+	}
+	return mcputil.NewStructuredResult(result, data), nil
+}
+
+// alertHistory is the structured form of an alert's history.
+type alertHistory struct {
+	AlertName string                       `json:"alertName"`
+	Current   int                          `json:"current" jsonschema:"number of current instances in Alertmanager"`
+	Offset    int                          `json:"offset" jsonschema:"index of the first returned instance or occurrence"`
+	Instances []alertmanager.GettableAlert `json:"instances" jsonschema:"current instances; empty when alert history answers"`
+	History   *historySummary              `json:"history,omitempty" jsonschema:"recorded history; present when alert history is enabled"`
+}
+
+// historySummary is the recorded history of an alert over a time window.
+type historySummary struct {
+	Window         string               `json:"window"`
+	Source         string               `json:"source" jsonschema:"where the history comes from"`
+	Since          time.Time            `json:"since" jsonschema:"start of the window"`
+	RecordingSince time.Time            `json:"recordingSince" jsonschema:"start of the recorded history; zero if unknown"`
+	Occurrences    int                  `json:"occurrences" jsonschema:"occurrences firing at some point in the window, across all pages"`
+	Fired          int                  `json:"fired" jsonschema:"occurrences that started in the window"`
+	FiringNow      int                  `json:"firingNow"`
+	Silenced       int                  `json:"silenced" jsonschema:"occurrences that were silenced at some point"`
+	FiringSeconds  int64                `json:"firingSeconds" jsonschema:"total firing time within the window"`
+	LongestSeconds int64                `json:"longestSeconds"`
+	AverageSeconds int64                `json:"averageSeconds"`
+	Items          []history.Occurrence `json:"items"`
+}
+
+func summarize(occurrences []history.Occurrence, window string, since, now time.Time) historySummary {
+	s := historySummary{Window: window, Since: since, Occurrences: len(occurrences)}
+	var total, firing, longest time.Duration
+	for i := range occurrences {
+		o := &occurrences[i]
+		if !o.StartsAt.Before(since) {
+			s.Fired++
+		}
+		if o.Open() {
+			s.FiringNow++
+		}
+		if o.Silenced() {
+			s.Silenced++
+		}
+		d := o.Duration(now)
+		total += d
+		longest = max(longest, d)
+		end := now
+		if o.EndsAt != nil {
+			end = *o.EndsAt
+		}
+		firing += end.Sub(timeutil.Later(o.StartsAt, since))
+	}
+	s.FiringSeconds = int64(firing.Seconds())
+	s.LongestSeconds = int64(longest.Seconds())
+	if len(occurrences) > 0 {
+		s.AverageSeconds = int64(total.Seconds()) / int64(len(occurrences))
+	}
+	return s
+}
+
+// sortOccurrences sorts occurrences by startsAt, endsAt, duration or any label name.
+func sortOccurrences(occurrences []history.Occurrence, sortBy string, now time.Time) error {
+	return output.Sort(occurrences, sortBy, func(key string) func(a, b history.Occurrence) int {
+		switch key {
+		case "startsAt":
+			return func(a, b history.Occurrence) int { return a.StartsAt.Compare(b.StartsAt) }
+		case "endsAt":
+			return func(a, b history.Occurrence) int { return endTime(a, now).Compare(endTime(b, now)) }
+		case "duration":
+			return func(a, b history.Occurrence) int { return cmp.Compare(a.Duration(now), b.Duration(now)) }
+		default:
+			return func(a, b history.Occurrence) int { return cmp.Compare(a.Labels[key], b.Labels[key]) }
+		}
+	})
+}
+
+func endTime(o history.Occurrence, now time.Time) time.Time {
+	if o.EndsAt != nil {
+		return *o.EndsAt
+	}
+	return now
+}
+
+func occurrencesTable(occurrences []history.Occurrence, now time.Time) output.Table {
+	t := output.Table{
+		Title:   "Occurrences",
+		Columns: []string{"fingerprint", "startsAt", "endsAt", "duration", "states", "notified", "labels"},
+	}
+	for _, o := range occurrences {
+		end := "firing"
+		if o.EndsAt != nil {
+			end = output.FormatTime(*o.EndsAt)
+		}
+		states := make([]string, len(o.Transitions))
+		for i, tr := range o.Transitions {
+			states[i] = tr.State
+		}
+		t.Rows = append(t.Rows, []string{
+			o.Fingerprint,
+			output.FormatTime(o.StartsAt),
+			end,
+			output.FormatDuration(o.Duration(now)),
+			strings.Join(states, " > "),
+			notified(o.Notifications),
+			output.FormatLabels(o.Labels, "alertname"),
+		})
+	}
+	return t
+}
+
+// notified summarizes notifications as the receivers with their number of
+// notifications, e.g. "pagerduty x3, slack".
+func notified(notifications []history.Notification) string {
+	counts := make(map[string]int)
+	var receivers []string
+	for _, n := range notifications {
+		if counts[n.Receiver] == 0 {
+			receivers = append(receivers, n.Receiver)
+		}
+		counts[n.Receiver]++
+	}
+	parts := make([]string, len(receivers))
+	for i, r := range receivers {
+		parts[i] = r
+		if counts[r] > 1 {
+			parts[i] += fmt.Sprintf(" x%d", counts[r])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func historyTable(alerts []alertmanager.GettableAlert) output.Table {
+	t := output.Table{
+		Title:   "Instances",
+		Columns: []string{"fingerprint", "state", "startsAt", "duration", "severity", "namespace"},
+	}
+	for _, a := range alerts {
+		t.Rows = append(t.Rows, []string{
+			a.Fingerprint,
+			a.Status.State,
+			output.FormatTime(a.StartsAt),
+			output.FormatDuration(time.Since(a.StartsAt)),
+			a.Labels["severity"],
+			a.Labels["namespace"],
+		})
+	}
+	return t
 }
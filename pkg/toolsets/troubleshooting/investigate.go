@@ -6,21 +6,31 @@ import (
 	"strings"
 	"time"
 
-	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/history"
 	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/mcputil"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
 )
 
-// Register registers all troubleshooting tools.
-func Register(s *mcp.Server, client *alertmanager.Client) {
+// Register registers all troubleshooting tools. source is the alert history;
+// nil if history is disabled.
+func Register(s *mcp.Server, client *alertmanager.Client, source history.Source) {
 	registerInvestigateAlert(s, client)
-	registerGetAlertHistory(s, client)
+	registerGetAlertHistory(s, client, source)
 	registerCorrelateAlerts(s, client)
 }
 
+type investigateAlertArgs struct {
+	AlertName string `json:"alertName" jsonschema:"Alert name to investigate"`
+	output.PageArgs
+	output.FieldsArgs
+	output.FormatArgs
+}
+
 func registerInvestigateAlert(s *mcp.Server, client *alertmanager.Client) {
+	input := mcputil.NewInput[investigateAlertArgs](output.PageSchema("startsAt, severity, state, or any label name"))
 	s.AddTool(&mcp.Tool{
 		Name:        "investigateAlert",
 		Description: "Investigate an alert: all instances, duration, labels, silences, recommendations.",
@@ -28,36 +38,27 @@ func registerInvestigateAlert(s *mcp.Server, client *alertmanager.Client) {
 			Title:        "Troubleshooting: Investigate Alert",
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
-			Type: "object",
-			Properties: map[string]*jsonschema.Schema{
-				"alertName": {
-					Type:        "string",
-					Description: "Alert name to investigate",
-				},
-			},
-			Required: []string{"alertName"},
-		},
+		InputSchema:  input.Schema(),
+		OutputSchema: mcputil.OutputSchema[investigation](),
 	}, func(ctx context.Context, request *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args, err := mcputil.GetArguments(request)
+		args, err := input.Bind(request)
 		if err != nil {
 			return mcputil.NewErrorResult(err.Error()), nil
 		}
 
-		alertName, _ := args["alertName"].(string)
+		alertName := args.AlertName
 		if alertName == "" {
 			return mcputil.NewErrorResult("alertName parameter is required"), nil
 		}
 
+		page := args.Page()
+
 		// Get all alerts (active + silenced + inhibited) for this alert name
 		alerts, err := client.GetAlertsRaw("true", "true", "true")
 		if err != nil {
 			return mcputil.NewErrorResult(fmt.Sprintf("Failed to get alerts: %v", err)), nil
 		}
 
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("=== Investigation: %s ===\n\n", alertName))
-
 		var matchingAlerts []alertmanager.GettableAlert
 		for _, a := range alerts {
 			if a.Labels["alertname"] == alertName {
@@ -65,39 +66,59 @@ func registerInvestigateAlert(s *mcp.Server, client *alertmanager.Client) {
 			}
 		}
 
-		if len(matchingAlerts) == 0 {
-			sb.WriteString("No instances found for this alert.\n")
-			return mcputil.NewTextResult(sb.String()), nil
+		if err := output.SortAlerts(matchingAlerts, page.SortBy); err != nil {
+			return mcputil.NewErrorResult(err.Error()), nil
 		}
-
-		sb.WriteString(fmt.Sprintf("Active Instances: %d\n\n", len(matchingAlerts)))
-
-		for i, a := range matchingAlerts {
-			sb.WriteString(fmt.Sprintf("--- Instance %d ---\n", i+1))
-			sb.WriteString(fmt.Sprintf("  State: %s\n", a.Status.State))
-			sb.WriteString(fmt.Sprintf("  Started: %s\n", a.StartsAt.Format(time.RFC3339)))
-			duration := time.Since(a.StartsAt).Truncate(time.Second)
-			sb.WriteString(fmt.Sprintf("  Duration: %s\n", duration))
-
-			sb.WriteString("  Labels:\n")
-			for k, v := range a.Labels {
-				sb.WriteString(fmt.Sprintf("    %s: %s\n", k, v))
+		var data investigation
+		render := func(items []alertmanager.GettableAlert) (string, any, error) {
+			data = investigation{
+				AlertName: alertName,
+				Total:     len(matchingAlerts),
+				Offset:    page.Offset,
+				Instances: output.Alerts(items, args.Fields),
 			}
-			if len(a.Annotations) > 0 {
-				sb.WriteString("  Annotations:\n")
-				for k, v := range a.Annotations {
-					sb.WriteString(fmt.Sprintf("    %s: %s\n", k, v))
-				}
+			report := output.Report{Title: "Investigation: " + alertName}
+			if len(matchingAlerts) == 0 {
+				report.Notes = []string{"No instances found for this alert."}
+			} else {
+				report.Notes = []string{fmt.Sprintf("Active Instances: %d", len(matchingAlerts))}
+				report.Tables = []output.Table{instancesTable(data.Instances)}
 			}
-			if len(a.Status.SilencedBy) > 0 {
-				sb.WriteString(fmt.Sprintf("  Silenced by: %s\n", strings.Join(a.Status.SilencedBy, ", ")))
-			}
-			if len(a.Status.InhibitedBy) > 0 {
-				sb.WriteString(fmt.Sprintf("  Inhibited by: %s\n", strings.Join(a.Status.InhibitedBy, ", ")))
-			}
-			sb.WriteString("\n")
+			return output.RenderPage(args.Format, data, report)
 		}
-
-		return mcputil.NewTextResult(sb.String()), nil
+		result, err := output.Paginate(matchingAlerts, page, "instances", args.Format, render)
+		if err != nil {
+			return mcputil.NewErrorResult(fmt.Sprintf("Failed to format instances: %v", err)), nil
+		}
+		return mcputil.NewStructuredResult(result, data), nil
 	})
 }
+
+// investigation is the structured form of an alert investigation.
+type investigation struct {
+	AlertName string                       `json:"alertName"`
+	Total     int                          `json:"total" jsonschema:"number of instances across all pages"`
+	Offset    int                          `json:"offset" jsonschema:"index of the first returned instance"`
+	Instances []alertmanager.GettableAlert `json:"instances"`
+}
+
+// instancesTable lists alert instances with their silence and inhibition status.
+func instancesTable(alerts []alertmanager.GettableAlert) output.Table {
+	t := output.Table{
+		Title:   "Instances",
+		Columns: []string{"fingerprint", "state", "startsAt", "duration", "labels", "annotations", "silencedBy", "inhibitedBy"},
+	}
+	for _, a := range alerts {
+		t.Rows = append(t.Rows, []string{
+			a.Fingerprint,
+			a.Status.State,
+			output.FormatTime(a.StartsAt),
+			output.FormatDuration(time.Since(a.StartsAt)),
+			output.FormatLabels(a.Labels, "alertname"),
+			output.FormatLabels(a.Annotations),
+			strings.Join(a.Status.SilencedBy, ", "),
+			strings.Join(a.Status.InhibitedBy, ", "),
+		})
+	}
+	return t
+}
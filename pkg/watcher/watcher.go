@@ -0,0 +1,231 @@
+// Package watcher periodically polls Alertmanager for alerts and silences and
+// reports what changed between two polls.
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/output"
+)
+
+// DefaultInterval is the default time between two polls.
+const DefaultInterval = 30 * time.Second
+
+// EventType is the kind of change found between two polls.
+type EventType string
+
+const (
+	// AlertStarted is sent when an alert fingerprint appears.
+	AlertStarted EventType = "alertStarted"
+	// AlertResolved is sent when an alert fingerprint disappears.
+	AlertResolved EventType = "alertResolved"
+	// AlertStateChanged is sent when an alert becomes suppressed or active
+	// again, or the silences or alerts suppressing it change.
+	AlertStateChanged EventType = "alertStateChanged"
+	// SilenceCreated is sent when a new active or pending silence appears.
+	SilenceCreated EventType = "silenceCreated"
+	// SilenceStarted is sent when a pending silence becomes active.
+	SilenceStarted EventType = "silenceStarted"
+	// SilenceExpired is sent when a silence expires or is deleted.
+	SilenceExpired EventType = "silenceExpired"
+)
+
+// Event is a change of a single alert or silence.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Alert is the alert as last seen; nil for silence events.
+	Alert *alertmanager.GettableAlert
+	// PreviousState is the alert state before an AlertStateChanged event.
+	PreviousState string
+	// Silence is the silence as last seen; nil for alert events.
+	Silence *alertmanager.GettableSilence
+}
+
+func (e Event) String() string {
+	switch {
+	case e.Alert != nil:
+		name := e.Alert.Labels["alertname"]
+		if labels := output.FormatLabels(e.Alert.Labels, "alertname"); labels != "" {
+			name += "{" + labels + "}"
+		}
+		if e.Type == AlertStateChanged && e.PreviousState == e.Alert.Status.State {
+			return fmt.Sprintf("%s %s: suppressing silences or alerts changed", e.Type, name)
+		}
+		if e.Type == AlertStateChanged {
+			return fmt.Sprintf("%s %s: %s -> %s", e.Type, name, e.PreviousState, e.Alert.Status.State)
+		}
+		return fmt.Sprintf("%s %s", e.Type, name)
+	case e.Silence != nil && e.Silence.Comment != "":
+		return fmt.Sprintf("%s %s (%s)", e.Type, e.Silence.ID, e.Silence.Comment)
+	case e.Silence != nil:
+		return fmt.Sprintf("%s %s", e.Type, e.Silence.ID)
+	}
+	return string(e.Type)
+}
+
+// Snapshot is the state of Alertmanager at one poll, keyed by alert
+// fingerprint and silence ID.
+type Snapshot struct {
+	Time     time.Time
+	Alerts   map[string]alertmanager.GettableAlert
+	Silences map[string]alertmanager.GettableSilence
+}
+
+// Handler is called after every successful poll with the new snapshot and
+// the events found since the previous poll, in a stable order. The first poll
+// only records a baseline and has no events.
+type Handler func(ctx context.Context, snapshot *Snapshot, events []Event)
+
+// Watcher polls Alertmanager and dispatches the changes to its handlers.
+type Watcher struct {
+	client   *alertmanager.Client
+	interval time.Duration
+
+	start    sync.Once
+	mu       sync.Mutex
+	handlers []Handler
+	last     *Snapshot
+}
+
+// New creates a watcher polling every interval.
+func New(client *alertmanager.Client, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Watcher{client: client, interval: interval}
+}
+
+// OnPoll registers a handler called after every successful poll.
+func (w *Watcher) OnPoll(h Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, h)
+}
+
+// Start runs the watcher in the background until ctx is done. Only the first
+// call starts it, so it can be called whenever polling becomes needed.
+func (w *Watcher) Start(ctx context.Context) {
+	w.start.Do(func() { go w.Run(ctx) })
+}
+
+// Run polls until ctx is done. Failed polls are logged and retried at the next interval.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Poll(ctx); err != nil {
+			klog.Warningf("Polling Alertmanager failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches a snapshot, diffs it against the previous one and dispatches
+// the events to the handlers.
+func (w *Watcher) Poll(ctx context.Context) error {
+	alerts, err := w.client.GetAlertsRaw("true", "true", "true")
+	if err != nil {
+		return fmt.Errorf("failed to get alerts: %w", err)
+	}
+	silences, err := w.client.GetSilences("")
+	if err != nil {
+		return fmt.Errorf("failed to get silences: %w", err)
+	}
+	cur := &Snapshot{
+		Time:     time.Now(),
+		Alerts:   make(map[string]alertmanager.GettableAlert, len(alerts)),
+		Silences: make(map[string]alertmanager.GettableSilence, len(silences)),
+	}
+	for _, a := range alerts {
+		cur.Alerts[a.Fingerprint] = a
+	}
+	for _, s := range silences {
+		cur.Silences[s.ID] = s
+	}
+
+	w.mu.Lock()
+	prev := w.last
+	w.last = cur
+	handlers := w.handlers
+	w.mu.Unlock()
+
+	var events []Event
+	if prev == nil {
+		klog.V(2).Infof("Watching %d alerts and %d silences", len(cur.Alerts), len(cur.Silences))
+	} else {
+		events = Diff(prev, cur)
+	}
+	for _, e := range events {
+		klog.V(2).Info(e.String())
+	}
+	for _, h := range handlers {
+		h(ctx, cur, events)
+	}
+	return nil
+}
+
+// Diff returns the changes between two snapshots: alerts first, then
+// silences, each ordered by fingerprint or ID.
+func Diff(prev, cur *Snapshot) []Event {
+	var events []Event
+	for _, fp := range keys(prev.Alerts, cur.Alerts) {
+		before, had := prev.Alerts[fp]
+		after, has := cur.Alerts[fp]
+		switch {
+		case !had:
+			events = append(events, Event{Type: AlertStarted, Time: cur.Time, Alert: &after})
+		case !has:
+			events = append(events, Event{Type: AlertResolved, Time: cur.Time, Alert: &before})
+		case before.Status.State != after.Status.State,
+			!slices.Equal(before.Status.SilencedBy, after.Status.SilencedBy),
+			!slices.Equal(before.Status.InhibitedBy, after.Status.InhibitedBy):
+			events = append(events, Event{Type: AlertStateChanged, Time: cur.Time, Alert: &after, PreviousState: before.Status.State})
+		}
+	}
+	for _, id := range keys(prev.Silences, cur.Silences) {
+		before, had := prev.Silences[id]
+		after, has := cur.Silences[id]
+		wasLive := had && before.Status.State != "expired"
+		switch {
+		case !had && has && after.Status.State != "expired":
+			events = append(events, Event{Type: SilenceCreated, Time: cur.Time, Silence: &after})
+		case wasLive && !has:
+			events = append(events, Event{Type: SilenceExpired, Time: cur.Time, Silence: &before})
+		case wasLive && after.Status.State == "expired":
+			events = append(events, Event{Type: SilenceExpired, Time: cur.Time, Silence: &after})
+		case had && has && before.Status.State == "pending" && after.Status.State == "active":
+			events = append(events, Event{Type: SilenceStarted, Time: cur.Time, Silence: &after})
+		}
+	}
+	return events
+}
+
+// keys returns the sorted union of the keys of two maps.
+func keys[V any](a, b map[string]V) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		set[k] = struct{}{}
+	}
+	for k := range b {
+		set[k] = struct{}{}
+	}
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jeanlopezxyz/mcp-alertmanager/pkg/alertmanager"
+)
+
+func alert(fp, state string, silencedBy ...string) alertmanager.GettableAlert {
+	a := alertmanager.GettableAlert{Fingerprint: fp, Labels: map[string]string{"alertname": "Down"}}
+	a.Status.State = state
+	a.Status.SilencedBy = silencedBy
+	return a
+}
+
+func silence(id, state string) alertmanager.GettableSilence {
+	s := alertmanager.GettableSilence{ID: id}
+	s.Status.State = state
+	return s
+}
+
+func snapshot(alerts []alertmanager.GettableAlert, silences []alertmanager.GettableSilence) *Snapshot {
+	s := &Snapshot{Alerts: make(map[string]alertmanager.GettableAlert), Silences: make(map[string]alertmanager.GettableSilence)}
+	for _, a := range alerts {
+		s.Alerts[a.Fingerprint] = a
+	}
+	for _, sil := range silences {
+		s.Silences[sil.ID] = sil
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		prev *Snapshot
+		cur  *Snapshot
+		want []string
+	}{
+		{"unchanged",
+			snapshot([]alertmanager.GettableAlert{alert("a", "active")}, []alertmanager.GettableSilence{silence("s", "active")}),
+			snapshot([]alertmanager.GettableAlert{alert("a", "active")}, []alertmanager.GettableSilence{silence("s", "active")}),
+			nil},
+		{"alerts",
+			snapshot([]alertmanager.GettableAlert{alert("a", "active"), alert("b", "active"), alert("c", "suppressed", "s1")}, nil),
+			snapshot([]alertmanager.GettableAlert{alert("b", "suppressed", "s1"), alert("c", "suppressed", "s2"), alert("d", "active")}, nil),
+			[]string{"alertResolved a", "alertStateChanged b", "alertStateChanged c", "alertStarted d"}},
+		{"silences",
+			snapshot(nil, []alertmanager.GettableSilence{silence("s1", "pending"), silence("s2", "active"), silence("s3", "active"), silence("s4", "expired")}),
+			snapshot(nil, []alertmanager.GettableSilence{silence("s1", "active"), silence("s2", "expired"), silence("s5", "pending"), silence("s6", "expired")}),
+			[]string{"silenceStarted s1", "silenceExpired s2", "silenceExpired s3", "silenceCreated s5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range Diff(tt.prev, tt.cur) {
+				id := ""
+				if e.Alert != nil {
+					id = e.Alert.Fingerprint
+				} else {
+					id = e.Silence.ID
+				}
+				got = append(got, string(e.Type)+" "+id)
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	var mu sync.Mutex
+	alerts := `[{"labels":{"alertname":"Down"},"fingerprint":"a","status":{"state":"active"}}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/v2/alerts" {
+			w.Write([]byte(alerts))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	w := New(alertmanager.NewClient(srv.URL, nil), time.Hour)
+	var polls [][]Event
+	w.OnPoll(func(ctx context.Context, snapshot *Snapshot, events []Event) {
+		polls = append(polls, events)
+	})
+	ctx := context.Background()
+	if err := w.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	alerts = `[]`
+	mu.Unlock()
+	if err := w.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(polls) != 2 || len(polls[0]) != 0 {
+		t.Fatalf("polls = %v, want a baseline without events and a second poll", polls)
+	}
+	if len(polls[1]) != 1 || polls[1][0].Type != AlertResolved {
+		t.Errorf("second poll events = %v, want alertResolved", polls[1])
+	}
+}
+
+func TestStartOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/api/v2/alerts" {
+			requests++
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	w := New(alertmanager.NewClient(srv.URL, nil), time.Hour)
+	polled := make(chan struct{}, 10)
+	w.OnPoll(func(context.Context, *Snapshot, []Event) { polled <- struct{}{} })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for range 3 {
+		w.Start(ctx)
+	}
+	<-polled
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("Start() three times polled %d times, want 1", requests)
+	}
+}